@@ -0,0 +1,84 @@
+package gospiget
+
+import (
+	"context"
+	"sync"
+)
+
+const defaultBulkConcurrency = 8
+
+// BulkOptions controls the bulk fetch helpers
+type BulkOptions struct {
+	// Concurrency is the maximum number of requests in flight. Defaults to 8.
+	Concurrency int
+}
+
+// bulkFetch calls fetch for every ID using a bounded worker pool. Results keep
+// the input order; IDs that fail are left as the zero value and recorded in
+// the returned error map, so one failure does not abort the batch.
+func bulkFetch[T any](ctx context.Context, ids []int, opts BulkOptions, fetch func(ctx context.Context, id int) (T, error)) ([]T, map[int]error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+
+	results := make([]T, len(ids))
+	errs := make(map[int]error)
+	var mu sync.Mutex
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(ids); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result, err := fetch(ctx, ids[i])
+				if err != nil {
+					mu.Lock()
+					errs[ids[i]] = err
+					mu.Unlock()
+					continue
+				}
+				results[i] = result
+			}
+		}()
+	}
+
+	for i := range ids {
+		if ctx.Err() != nil {
+			mu.Lock()
+			errs[ids[i]] = ctx.Err()
+			mu.Unlock()
+			continue
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, errs
+}
+
+// GetResourcesByIDs fetches many resources concurrently. A deleted resource
+// shows up as a nil entry and a NotFoundError in the error map.
+func (c *Client) GetResourcesByIDs(ctx context.Context, ids []int, opts BulkOptions) ([]*Resource, map[int]error) {
+	return bulkFetch(ctx, ids, opts, func(ctx context.Context, id int) (*Resource, error) {
+		return c.resource(ctx, id, nil)
+	})
+}
+
+// GetAuthorsByIDs fetches many authors concurrently
+func (c *Client) GetAuthorsByIDs(ctx context.Context, ids []int, opts BulkOptions) ([]*Author, map[int]error) {
+	return bulkFetch(ctx, ids, opts, func(ctx context.Context, id int) (*Author, error) {
+		return c.author(ctx, id, nil)
+	})
+}
+
+// GetResourceVersionsByIDs fetches many versions of one resource concurrently.
+// The error map is keyed by version ID.
+func (c *Client) GetResourceVersionsByIDs(ctx context.Context, resourceID int, versionIDs []int, opts BulkOptions) ([]*ResourceVersion, map[int]error) {
+	return bulkFetch(ctx, versionIDs, opts, func(ctx context.Context, id int) (*ResourceVersion, error) {
+		return c.resourceVersion(ctx, resourceID, id, nil)
+	})
+}
@@ -0,0 +1,53 @@
+package gospiget
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResourcesByIDs(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		id := strings.TrimPrefix(r.URL.Path, "/resources/")
+		if id == "404" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"id": %s, "name": "Resource %s"}`, id, id)
+	}))
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL))
+	ids := []int{5, 3, 404, 1, 9, 7, 2, 8}
+	resources, errs := c.GetResourcesByIDs(context.Background(), ids, BulkOptions{Concurrency: 3})
+
+	assert.Len(t, resources, len(ids))
+	for i, id := range ids {
+		if id == 404 {
+			assert.Nil(t, resources[i])
+			continue
+		}
+		assert.Equal(t, id, resources[i].ID)
+	}
+	assert.Len(t, errs, 1)
+	assert.IsType(t, &NotFoundError{}, errs[404])
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(3))
+}
@@ -0,0 +1,261 @@
+package gospiget
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCacheMiss is returned by a KVStore when the requested key does not exist
+var ErrCacheMiss = errors.New("cache miss")
+
+// Cache stores raw endpoint responses keyed by request URL
+type Cache interface {
+	// Get returns the cached value and whether it was found
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores a value that expires after ttl. A ttl of zero means no expiry.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes a value from the cache
+	Delete(ctx context.Context, key string) error
+}
+
+// memorySweepInterval is how often MemoryCache drops expired entries that were never read again
+const memorySweepInterval = time.Minute
+
+type memoryEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// MemoryCache is an in-process Cache safe for concurrent use.
+// Expired entries are removed when read and swept periodically on writes.
+type MemoryCache struct {
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	nextSweep time.Time
+}
+
+// NewMemoryCache creates an empty in-memory cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]memoryEntry)}
+}
+
+func (m *MemoryCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+	return entry.value, true, nil
+}
+
+func (m *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	entry := memoryEntry{value: value}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+	m.mu.Lock()
+	m.entries[key] = entry
+	if now := time.Now(); now.After(m.nextSweep) {
+		for k, e := range m.entries {
+			if !e.expires.IsZero() && now.After(e.expires) {
+				delete(m.entries, k)
+			}
+		}
+		m.nextSweep = now.Add(memorySweepInterval)
+	}
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *MemoryCache) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	delete(m.entries, key)
+	m.mu.Unlock()
+	return nil
+}
+
+// FileCache is a Cache that keeps one file per key in a directory.
+// Each file starts with the expiry as 8 bytes of Unix nanoseconds (0 for none).
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache creates a filesystem cache rooted at dir, creating it if needed
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+func (f *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:]))
+}
+
+func (f *FileCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	data, err := os.ReadFile(f.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if len(data) < 8 {
+		return nil, false, nil
+	}
+	expires := int64(binary.BigEndian.Uint64(data[:8]))
+	if expires != 0 && time.Now().UnixNano() > expires {
+		_ = os.Remove(f.path(key))
+		return nil, false, nil
+	}
+	return data[8:], true, nil
+}
+
+func (f *FileCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	var expires int64
+	if ttl > 0 {
+		expires = time.Now().Add(ttl).UnixNano()
+	}
+	data := make([]byte, 8+len(value))
+	binary.BigEndian.PutUint64(data[:8], uint64(expires))
+	copy(data[8:], value)
+
+	tmp, err := os.CreateTemp(f.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path(key))
+}
+
+func (f *FileCache) Delete(ctx context.Context, key string) error {
+	err := os.Remove(f.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// KVStore is the subset of a Redis or memcached client needed by KVCache.
+// Get must return ErrCacheMiss when the key does not exist.
+type KVStore interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+// KVCache adapts a KVStore to the Cache interface, namespacing keys with a prefix
+type KVCache struct {
+	store  KVStore
+	prefix string
+}
+
+// NewKVCache creates a Cache backed by a shared key-value store
+func NewKVCache(store KVStore, prefix string) *KVCache {
+	return &KVCache{store: store, prefix: prefix}
+}
+
+func (k *KVCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := k.store.Get(ctx, k.prefix+key)
+	if errors.Is(err, ErrCacheMiss) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (k *KVCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return k.store.Set(ctx, k.prefix+key, value, ttl)
+}
+
+func (k *KVCache) Delete(ctx context.Context, key string) error {
+	return k.store.Delete(ctx, k.prefix+key)
+}
+
+// uncachedPath matches download and webhook endpoints relative to the API root
+var uncachedPath = regexp.MustCompile(`^/(resources/\d+(/versions/[^/]+)?/download(/proxy)?|webhook/.*)$`)
+
+// cacheTransport serves successful GET responses from a Cache. Downloads,
+// webhook status checks and requests sent with "Cache-Control: no-cache"
+// always go to the server.
+// Cache errors are ignored so a broken backend only costs a request.
+type cacheTransport struct {
+	cache Cache
+	ttl   time.Duration
+	// base is the path of the API root, e.g. "/v2"
+	base string
+	next http.RoundTripper
+}
+
+// bypass reports whether req must not be served from or stored in the cache.
+// The escaped path is used so that escaped search queries never look like
+// download or webhook paths.
+func (t *cacheTransport) bypass(req *http.Request) bool {
+	if req.Method != http.MethodGet || req.Header.Get("Cache-Control") == "no-cache" {
+		return true
+	}
+	path := req.URL.EscapedPath()
+	return strings.HasPrefix(path, t.base) && uncachedPath.MatchString(strings.TrimPrefix(path, t.base))
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.bypass(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	if body, ok, err := t.cache.Get(req.Context(), key); err == nil && ok {
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	_ = t.cache.Set(req.Context(), key, body, t.ttl)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
@@ -0,0 +1,136 @@
+package gospiget
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeKVStore stands in for a Redis or memcached client
+type fakeKVStore struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (f *fakeKVStore) Get(ctx context.Context, key string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	value, ok := f.data[key]
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+	return value, nil
+}
+
+func (f *fakeKVStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.data[key] = value
+	return nil
+}
+
+func (f *fakeKVStore) Delete(ctx context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.data, key)
+	return nil
+}
+
+func TestCacheImplementations(t *testing.T) {
+	fileCache, err := NewFileCache(t.TempDir())
+	assert.NoError(t, err)
+
+	caches := map[string]Cache{
+		"memory": NewMemoryCache(),
+		"file":   fileCache,
+		"kv":     NewKVCache(&fakeKVStore{data: map[string][]byte{}}, "spiget:"),
+	}
+
+	ctx := context.Background()
+	for name, cache := range caches {
+		t.Run(name, func(t *testing.T) {
+			_, ok, err := cache.Get(ctx, "missing")
+			assert.NoError(t, err)
+			assert.False(t, ok)
+
+			assert.NoError(t, cache.Set(ctx, "key", []byte("value"), time.Minute))
+			value, ok, err := cache.Get(ctx, "key")
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("value"), value)
+
+			assert.NoError(t, cache.Delete(ctx, "key"))
+			_, ok, err = cache.Get(ctx, "key")
+			assert.NoError(t, err)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	cache := NewMemoryCache()
+	ctx := context.Background()
+	assert.NoError(t, cache.Set(ctx, "key", []byte("value"), time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	_, ok, err := cache.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestMemoryCacheSweep(t *testing.T) {
+	cache := NewMemoryCache()
+	ctx := context.Background()
+	assert.NoError(t, cache.Set(ctx, "stale", []byte("value"), time.Millisecond))
+	assert.NoError(t, cache.Set(ctx, "kept", []byte("value"), 0))
+	time.Sleep(5 * time.Millisecond)
+
+	// Expired entries that are never read again are dropped by the next sweep
+	cache.nextSweep = time.Time{}
+	assert.NoError(t, cache.Set(ctx, "new", []byte("value"), time.Minute))
+	assert.Len(t, cache.entries, 2)
+	assert.NotContains(t, cache.entries, "stale")
+}
+
+func TestCacheBypass(t *testing.T) {
+	transport := &cacheTransport{base: "/v2"}
+	tests := []struct {
+		path   string
+		bypass bool
+	}{
+		{"/v2/resources/1", false},
+		{"/v2/resources/1/download", true},
+		{"/v2/resources/1/versions/2/download", true},
+		{"/v2/resources/1/versions/2/download/proxy", true},
+		{"/v2/webhook/status/abc", true},
+		{"/v2/search/resources/download", false},
+		{"/v2/search/resources/webhook%2Fx", false},
+		{"/v2/search/resources/1%2Fdownload", false},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, "http://api.example.com"+test.path, nil)
+		assert.Equal(t, test.bypass, transport.bypass(req), test.path)
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Write([]byte(`{"id": 1, "name": "Example"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL), WithCache(NewMemoryCache(), time.Minute))
+	for i := 0; i < 3; i++ {
+		resource, err := c.GetResourceByID(1)
+		assert.NoError(t, err)
+		assert.Equal(t, "Example", resource.Name)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+}
@@ -1,10 +1,14 @@
 package gospiget
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"math/rand"
@@ -26,323 +30,220 @@ func getRandomUserAgent() string {
 }
 
 type Client struct {
-	restyClient *resty.Client
+	restyClient    *resty.Client
+	iconClient     *resty.Client
+	baseURL        string
+	cache          Cache
+	cacheTTL       time.Duration
+	iconCache      Cache
+	iconCacheTTL   time.Duration
+	flights        flightGroup
+	quota          *quotaTracker
+	middleware     []Middleware
+	iconMiddleware []Middleware
 }
 
-func NewClient() *Client {
-	client := resty.New()
-	client.SetBaseURL(baseURL)
-	client.SetTimeout(10 * time.Second)
-	client.SetHeader("User-Agent", getRandomUserAgent())
-	return &Client{restyClient: client}
-}
+// Option configures a Client
+type Option func(*Client)
 
-func (c *Client) GetStatus() (map[string]interface{}, error) {
-	resp, err := c.restyClient.R().Get("/status")
-	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
-	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
-	}
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp.Body(), &result); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
+// WithBaseURL points the client at a different Spiget API root, e.g. a mirror
+func WithBaseURL(url string) Option {
+	return func(c *Client) {
+		c.baseURL = url
 	}
-	return result, nil
 }
 
-func (c *Client) GetResources(params map[string]string) ([]Resource, error) {
-	resp, err := c.restyClient.R().SetQueryParams(params).Get("/resources")
-	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
-	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
-	}
-	var resources []Resource
-	if err := json.Unmarshal(resp.Body(), &resources); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
+// WithCache stores successful endpoint responses in cache for ttl.
+// Downloads and webhook status checks are never cached.
+func WithCache(cache Cache, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cache = cache
+		c.cacheTTL = ttl
 	}
-	return resources, nil
 }
 
-func (c *Client) GetResourceByID(resourceID int) (*Resource, error) {
-	resp, err := c.restyClient.R().Get(fmt.Sprintf("/resources/%d", resourceID))
+// apiPath returns the path of the API root in baseURL without a trailing slash
+func apiPath(baseURL string) string {
+	u, err := url.Parse(baseURL)
 	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
+		return ""
 	}
-	if resp.StatusCode() == http.StatusNotFound {
-		return nil, &NotFoundError{Message: "resource not found"}
+	return strings.TrimSuffix(u.EscapedPath(), "/")
+}
+
+func NewClient(opts ...Option) *Client {
+	c := &Client{baseURL: baseURL, quota: newQuotaTracker()}
+	for _, opt := range opts {
+		opt(c)
 	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
+
+	client := resty.New()
+	client.SetBaseURL(c.baseURL)
+	client.SetTimeout(10 * time.Second)
+	client.SetHeader("User-Agent", getRandomUserAgent())
+	client.OnAfterResponse(c.quota.onAfterResponse)
+	transport := client.GetClient().Transport
+	if c.cache != nil {
+		transport = &cacheTransport{
+			cache: c.cache,
+			ttl:   c.cacheTTL,
+			base:  apiPath(c.baseURL),
+			next:  transport,
+		}
+		c.iconCache, c.iconCacheTTL = c.cache, c.cacheTTL
+	} else {
+		c.iconCache, c.iconCacheTTL = NewMemoryCache(), iconCacheTTL
 	}
-	var resource Resource
-	if err := json.Unmarshal(resp.Body(), &resource); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
+	client.SetTransport(chain(transport, c.middleware))
+	c.restyClient = client
+
+	// Icons are served by SpigotMC, so they skip the API middleware and quota
+	c.iconClient = resty.New()
+	c.iconClient.SetTimeout(10 * time.Second)
+	c.iconClient.SetHeader("User-Agent", client.Header.Get("User-Agent"))
+	c.iconClient.SetTransport(chain(c.iconClient.GetClient().Transport, c.iconMiddleware))
+	return c
+}
+
+func (c *Client) GetStatus() (*Status, error) {
+	return c.status(context.Background(), false)
+}
+
+func (c *Client) GetResources(params map[string]string) ([]Resource, error) {
+	return get[[]Resource](context.Background(), c, "/resources", params, notFound{message: "resources not found"})
+}
+
+func (c *Client) GetNewResources(params map[string]string) ([]Resource, error) {
+	return c.newResources(context.Background(), params)
+}
+
+// GetNewResourcesSince pages through the newest resources and returns every
+// resource released at or after since, newest first. It sends one request per
+// 100 resources, so an old or zero since walks the whole listing; cancel ctx
+// to stop early.
+func (c *Client) GetNewResourcesSince(ctx context.Context, since time.Time) ([]Resource, error) {
+	const pageSize = 100
+	var result []Resource
+	for page := 1; ; page++ {
+		resources, err := c.newResources(ctx, map[string]string{
+			"size": strconv.Itoa(pageSize),
+			"page": strconv.Itoa(page),
+			"sort": "-releaseDate",
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range resources {
+			if resource.ReleaseDate.Time().Before(since) {
+				return result, nil
+			}
+			result = append(result, resource)
+		}
+		if len(resources) < pageSize {
+			return result, nil
+		}
 	}
-	return &resource, nil
 }
 
-func (c *Client) GetResourceAuthor(resourceID int) (*Author, error) {
-	resp, err := c.restyClient.R().Get(fmt.Sprintf("/resources/%d/author", resourceID))
-	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
+func (c *Client) GetFreeResources(params map[string]string) ([]Resource, error) {
+	return get[[]Resource](context.Background(), c, "/resources/free", params, notFound{message: "free resources not found"})
+}
+
+func (c *Client) GetPremiumResources(params map[string]string) ([]Resource, error) {
+	return get[[]Resource](context.Background(), c, "/resources/premium", params, notFound{message: "premium resources not found"})
+}
+
+// GetResourcesForVersions retrieves resources tested for the given Minecraft
+// versions. At least one version is required, and an empty method means MatchAny.
+func (c *Client) GetResourcesForVersions(versions []string, method MatchMethod, params map[string]string) (*ResourcesForVersions, error) {
+	if len(versions) == 0 {
+		return nil, errors.New("no versions given")
 	}
-	if resp.StatusCode() == http.StatusNotFound {
-		return nil, &NotFoundError{Message: "resource author not found"}
+	if method == "" {
+		method = MatchAny
 	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
+	query := map[string]string{"method": string(method)}
+	for k, v := range params {
+		query[k] = v
 	}
-	var author Author
-	if err := json.Unmarshal(resp.Body(), &author); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
+	escaped := make([]string, len(versions))
+	for i, version := range versions {
+		escaped[i] = url.PathEscape(version)
 	}
-	return &author, nil
+	return get[*ResourcesForVersions](context.Background(), c, fmt.Sprintf("/resources/for/%s", strings.Join(escaped, ",")), query, lookup("resources for versions", strings.Join(versions, ",")))
+}
+
+func (c *Client) GetResourceByID(resourceID int, fields ...string) (*Resource, error) {
+	return c.resource(context.Background(), resourceID, fields)
+}
+
+func (c *Client) GetResourceAuthor(resourceID int, fields ...string) (*Author, error) {
+	return get[*Author](context.Background(), c, fmt.Sprintf("/resources/%d/author", resourceID), fieldParams(fields), notFound{"resource author not found", "resource", strconv.Itoa(resourceID)})
 }
 
 func (c *Client) GetResourceVersions(resourceID int, params map[string]string) ([]ResourceVersion, error) {
-	resp, err := c.restyClient.R().SetQueryParams(params).Get(fmt.Sprintf("/resources/%d/versions", resourceID))
-	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
-	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
-	}
-	var versions []ResourceVersion
-	if err := json.Unmarshal(resp.Body(), &versions); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
-	}
-	return versions, nil
+	return c.resourceVersions(context.Background(), resourceID, params)
 }
 
-func (c *Client) GetResourceVersionByID(resourceID, versionID int) (*ResourceVersion, error) {
-	resp, err := c.restyClient.R().Get(fmt.Sprintf("/resources/%d/versions/%d", resourceID, versionID))
-	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
-	}
-	if resp.StatusCode() == http.StatusNotFound {
-		return nil, &NotFoundError{Message: "resource version not found"}
-	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
-	}
-	var version ResourceVersion
-	if err := json.Unmarshal(resp.Body(), &version); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
-	}
-	return &version, nil
+func (c *Client) GetResourceVersionByID(resourceID, versionID int, fields ...string) (*ResourceVersion, error) {
+	return c.resourceVersion(context.Background(), resourceID, versionID, fields)
 }
 
-func (c *Client) GetLatestResourceVersion(resourceID int) (*ResourceVersion, error) {
-	resp, err := c.restyClient.R().Get(fmt.Sprintf("/resources/%d/versions/latest", resourceID))
-	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
-	}
-	if resp.StatusCode() == http.StatusNotFound {
-		return nil, &NotFoundError{Message: "latest resource version not found"}
-	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
-	}
-	var version ResourceVersion
-	if err := json.Unmarshal(resp.Body(), &version); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
-	}
-	return &version, nil
+func (c *Client) GetLatestResourceVersion(resourceID int, fields ...string) (*ResourceVersion, error) {
+	return c.latestVersion(context.Background(), resourceID, fields, false)
 }
 
 func (c *Client) GetResourceUpdates(resourceID int, params map[string]string) ([]ResourceUpdate, error) {
-	resp, err := c.restyClient.R().SetQueryParams(params).Get(fmt.Sprintf("/resources/%d/updates", resourceID))
-	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
-	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
-	}
-	var updates []ResourceUpdate
-	if err := json.Unmarshal(resp.Body(), &updates); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
-	}
-	return updates, nil
+	return get[[]ResourceUpdate](context.Background(), c, fmt.Sprintf("/resources/%d/updates", resourceID), params, lookup("resource", resourceID))
 }
 
-func (c *Client) GetLatestResourceUpdate(resourceID int) (*ResourceUpdate, error) {
-	resp, err := c.restyClient.R().Get(fmt.Sprintf("/resources/%d/updates/latest", resourceID))
-	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
-	}
-	if resp.StatusCode() == http.StatusNotFound {
-		return nil, &NotFoundError{Message: "latest resource update not found"}
-	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
-	}
-	var update ResourceUpdate
-	if err := json.Unmarshal(resp.Body(), &update); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
-	}
-	return &update, nil
+func (c *Client) GetLatestResourceUpdate(resourceID int, fields ...string) (*ResourceUpdate, error) {
+	return c.latestUpdate(context.Background(), resourceID, fields, false)
 }
 
 func (c *Client) GetResourceReviews(resourceID int, params map[string]string) ([]ResourceReview, error) {
-	resp, err := c.restyClient.R().SetQueryParams(params).Get(fmt.Sprintf("/resources/%d/reviews", resourceID))
-	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
-	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
-	}
-	var reviews []ResourceReview
-	if err := json.Unmarshal(resp.Body(), &reviews); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
-	}
-	return reviews, nil
+	return c.resourceReviews(context.Background(), resourceID, params)
 }
 
 func (c *Client) GetAuthors(params map[string]string) ([]Author, error) {
-	resp, err := c.restyClient.R().SetQueryParams(params).Get("/authors")
-	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
-	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
-	}
-	var authors []Author
-	if err := json.Unmarshal(resp.Body(), &authors); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
-	}
-	return authors, nil
+	return get[[]Author](context.Background(), c, "/authors", params, notFound{message: "authors not found"})
 }
 
-func (c *Client) GetAuthorByID(authorID int) (*Author, error) {
-	resp, err := c.restyClient.R().Get(fmt.Sprintf("/authors/%d", authorID))
-	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
-	}
-	if resp.StatusCode() == http.StatusNotFound {
-		return nil, &NotFoundError{Message: "author not found"}
-	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
-	}
-	var author Author
-	if err := json.Unmarshal(resp.Body(), &author); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
-	}
-	return &author, nil
+func (c *Client) GetAuthorByID(authorID int, fields ...string) (*Author, error) {
+	return c.author(context.Background(), authorID, fields)
 }
 
 func (c *Client) GetAuthorResources(authorID int, params map[string]string) ([]Resource, error) {
-	resp, err := c.restyClient.R().SetQueryParams(params).Get(fmt.Sprintf("/authors/%d/resources", authorID))
-	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
-	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
-	}
-	var resources []Resource
-	if err := json.Unmarshal(resp.Body(), &resources); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
-	}
-	return resources, nil
+	return c.authorResources(context.Background(), authorID, params, false)
 }
 
 func (c *Client) GetAuthorReviews(authorID int, params map[string]string) ([]ResourceReview, error) {
-	resp, err := c.restyClient.R().SetQueryParams(params).Get(fmt.Sprintf("/authors/%d/reviews", authorID))
-	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
-	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
-	}
-	var reviews []ResourceReview
-	if err := json.Unmarshal(resp.Body(), &reviews); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
-	}
-	return reviews, nil
+	return get[[]ResourceReview](context.Background(), c, fmt.Sprintf("/authors/%d/reviews", authorID), params, lookup("author", authorID))
 }
 
 func (c *Client) GetCategories(params map[string]string) ([]Category, error) {
-	resp, err := c.restyClient.R().SetQueryParams(params).Get("/categories")
-	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
-	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
-	}
-	var categories []Category
-	if err := json.Unmarshal(resp.Body(), &categories); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
-	}
-	return categories, nil
+	return get[[]Category](context.Background(), c, "/categories", params, notFound{message: "categories not found"})
 }
 
-func (c *Client) GetCategoryByID(categoryID int) (*Category, error) {
-	resp, err := c.restyClient.R().Get(fmt.Sprintf("/categories/%d", categoryID))
-	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
-	}
-	if resp.StatusCode() == http.StatusNotFound {
-		return nil, &NotFoundError{Message: "category not found"}
-	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
-	}
-	var category Category
-	if err := json.Unmarshal(resp.Body(), &category); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
-	}
-	return &category, nil
+func (c *Client) GetCategoryByID(categoryID int, fields ...string) (*Category, error) {
+	return c.category(context.Background(), categoryID, fields)
 }
 
 func (c *Client) GetCategoryResources(categoryID int, params map[string]string) ([]Resource, error) {
-	resp, err := c.restyClient.R().SetQueryParams(params).Get(fmt.Sprintf("/categories/%d/resources", categoryID))
-	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
-	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
-	}
-	var resources []Resource
-	if err := json.Unmarshal(resp.Body(), &resources); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
-	}
-	return resources, nil
+	return c.categoryResources(context.Background(), categoryID, params, false)
 }
 
 func (c *Client) SearchResources(query string, params map[string]string) ([]Resource, error) {
-	resp, err := c.restyClient.R().SetQueryParams(params).Get(fmt.Sprintf("/search/resources/%s", query))
-	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
-	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
-	}
-	var resources []Resource
-	if err := json.Unmarshal(resp.Body(), &resources); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
-	}
-	return resources, nil
+	return get[[]Resource](context.Background(), c, fmt.Sprintf("/search/resources/%s", url.PathEscape(query)), params, lookup("resource search", query))
 }
 
 func (c *Client) SearchAuthors(query string, params map[string]string) ([]Author, error) {
-	resp, err := c.restyClient.R().SetQueryParams(params).Get(fmt.Sprintf("/search/authors/%s", query))
-	if err != nil {
-		return nil, &RequestError{Message: err.Error()}
-	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
-	}
-	var authors []Author
-	if err := json.Unmarshal(resp.Body(), &authors); err != nil {
-		return nil, &UnmarshalError{Message: err.Error()}
-	}
-	return authors, nil
+	return get[[]Author](context.Background(), c, fmt.Sprintf("/search/authors/%s", url.PathEscape(query)), params, lookup("author search", query))
 }
 
+// DownloadResourceVersion downloads a version to path. When the version is the
+// resource's current one, the download is checked against the reported file
+// size and a SizeMismatchError is returned without writing the file.
 func (c *Client) DownloadResourceVersion(resource ResourceVersion, path string, proxy bool) error {
 	var url string
 	if proxy {
@@ -351,13 +252,20 @@ func (c *Client) DownloadResourceVersion(resource ResourceVersion, path string,
 		url = fmt.Sprintf("/resources/%d/versions/%d/download", resource.ResourceId, resource.ID)
 	}
 
-	resp, err := c.restyClient.R().Get(url)
+	body, _, err := do[[]byte](context.Background(), c, request{
+		method:   http.MethodGet,
+		path:     url,
+		notFound: lookup("resource version", resource.ID),
+	})
 	if err != nil {
-		return &RequestError{Message: err.Error()}
+		return err
 	}
 
-	if resp.StatusCode() != http.StatusOK {
-		return &UnexpectedStatusCodeError{StatusCode: resp.StatusCode()}
+	if expected := c.expectedFile(resource); expected != nil {
+		actual := int64(len(body))
+		if diff := actual - expected.Bytes(); diff > expected.sizeTolerance() || -diff > expected.sizeTolerance() {
+			return &SizeMismatchError{Expected: expected.Bytes(), Actual: actual}
+		}
 	}
 
 	file, err := os.Create(path)
@@ -366,10 +274,21 @@ func (c *Client) DownloadResourceVersion(resource ResourceVersion, path string,
 	}
 	defer file.Close()
 
-	_, err = file.Write(resp.Body())
+	_, err = file.Write(body)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// expectedFile returns the file information for version if the API reports a
+// size for it. Spiget only describes the current version's file, and a failed
+// lookup skips the check rather than the download.
+func (c *Client) expectedFile(version ResourceVersion) *ResourceFile {
+	resource, err := c.resource(context.Background(), version.ResourceId, []string{"file", "version"})
+	if err != nil || resource.File == nil || resource.Version.ID != version.ID || resource.File.Bytes() == 0 {
+		return nil
+	}
+	return resource.File
+}
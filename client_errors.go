@@ -1,39 +1,218 @@
 package gospiget
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Sentinel errors for use with errors.Is
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrRateLimited = errors.New("rate limited")
+	ErrServerError = errors.New("server error")
+)
+
+// maxBodySnippet is how much of a response body is kept in an error
+const maxBodySnippet = 512
+
+// requestIDHeaders are checked in order for an identifier of the request
+var requestIDHeaders = []string{"X-Request-Id", "CF-Ray"}
+
+// ResponseInfo describes the request and response an error occurred for
+type ResponseInfo struct {
+	Method     string
+	URL        string
+	StatusCode int
+	// Body is the start of the response body
+	Body      string
+	RequestID string
+}
+
+func (i ResponseInfo) describe() string {
+	s := fmt.Sprintf("%s %s", i.Method, i.URL)
+	if i.RequestID != "" {
+		s += fmt.Sprintf(", request id %s", i.RequestID)
+	}
+	return s
+}
+
+func newResponseInfo(req *resty.Request, resp *resty.Response) ResponseInfo {
+	info := ResponseInfo{Method: req.Method, URL: req.URL}
+	if resp == nil || resp.RawResponse == nil {
+		return info
+	}
+	info.StatusCode = resp.StatusCode()
+	body := resp.Body()
+	if len(body) > maxBodySnippet {
+		body = body[:maxBodySnippet]
+	}
+	info.Body = string(body)
+	for _, header := range requestIDHeaders {
+		if id := resp.Header().Get(header); id != "" {
+			info.RequestID = id
+			break
+		}
+	}
+	return info
+}
+
+// statusSentinel returns the sentinel error matching a status code, if any
+func statusSentinel(statusCode int) error {
+	switch {
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case statusCode >= 500:
+		return ErrServerError
+	}
+	return nil
+}
 
 // NotFoundError represents a 404 Not Found error
 type NotFoundError struct {
 	Message string
+	// Entity is the type of the entity that was looked up, e.g. "resource"
+	Entity string
+	// ID identifies the entity, e.g. a resource ID or a search query
+	ID string
+	ResponseInfo
+}
+
+// notFound describes what a request looks up, for a NotFoundError on 404
+type notFound struct {
+	message string
+	entity  string
+	id      string
+}
+
+// lookup describes a request for a single entity
+func lookup(entity string, id interface{}) notFound {
+	return notFound{message: entity + " not found", entity: entity, id: fmt.Sprint(id)}
+}
+
+func newNotFoundError(req *resty.Request, resp *resty.Response, nf notFound) *NotFoundError {
+	return &NotFoundError{Message: nf.message, Entity: nf.entity, ID: nf.id, ResponseInfo: newResponseInfo(req, resp)}
 }
 
 func (e *NotFoundError) Error() string {
-	return fmt.Sprintf("Not Found: %s", e.Message)
+	if e.URL == "" {
+		return fmt.Sprintf("Not Found: %s", e.Message)
+	}
+	return fmt.Sprintf("Not Found: %s (%s)", e.Message, e.describe())
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
 }
 
 // UnexpectedStatusCodeError represents an unexpected status code error
 type UnexpectedStatusCodeError struct {
 	StatusCode int
+	ResponseInfo
+}
+
+// newStatusError returns a RateLimitError for 429 responses and an
+// UnexpectedStatusCodeError otherwise
+func newStatusError(req *resty.Request, resp *resty.Response) error {
+	info := newResponseInfo(req, resp)
+	if resp.StatusCode() == http.StatusTooManyRequests {
+		h := parseRateLimitHeaders(resp.Header(), time.Now())
+		return &RateLimitError{
+			RetryAfter:   h.retryAfter,
+			Limit:        h.limit,
+			Remaining:    h.remaining,
+			Reset:        h.reset,
+			ResponseInfo: info,
+		}
+	}
+	return &UnexpectedStatusCodeError{StatusCode: resp.StatusCode(), ResponseInfo: info}
 }
 
 func (e *UnexpectedStatusCodeError) Error() string {
-	return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+	if e.URL == "" {
+		return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected status code: %d (%s)", e.StatusCode, e.describe())
+}
+
+// Is matches ErrNotFound, ErrRateLimited and ErrServerError by status code
+func (e *UnexpectedStatusCodeError) Is(target error) bool {
+	sentinel := statusSentinel(e.StatusCode)
+	return sentinel != nil && target == sentinel
+}
+
+// RateLimitError represents a 429 Too Many Requests response
+type RateLimitError struct {
+	// RetryAfter is how long the API asked to wait, zero when not sent
+	RetryAfter time.Duration
+	// Limit and Remaining come from the rate-limit headers, -1 when not sent
+	Limit     int
+	Remaining int
+	// Reset is when the limit resets, zero when not sent
+	Reset time.Time
+	ResponseInfo
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("rate limited, retry after %s (%s)", e.RetryAfter, e.describe())
+	}
+	return fmt.Sprintf("rate limited (%s)", e.describe())
+}
+
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
 }
 
 // UnmarshalError represents an error during unmarshalling
 type UnmarshalError struct {
 	Message string
+	Err     error
+	ResponseInfo
+}
+
+func newUnmarshalError(req *resty.Request, resp *resty.Response, err error) *UnmarshalError {
+	return &UnmarshalError{Message: err.Error(), Err: err, ResponseInfo: newResponseInfo(req, resp)}
 }
 
 func (e *UnmarshalError) Error() string {
 	return fmt.Sprintf("failed to unmarshal response: %s", e.Message)
 }
 
+func (e *UnmarshalError) Unwrap() error {
+	return e.Err
+}
+
 // RequestError represents an error during the request
 type RequestError struct {
 	Message string
+	Err     error
+	ResponseInfo
+}
+
+func newRequestError(req *resty.Request, err error) *RequestError {
+	return &RequestError{Message: err.Error(), Err: err, ResponseInfo: newResponseInfo(req, nil)}
 }
 
 func (e *RequestError) Error() string {
 	return fmt.Sprintf("request error: %s", e.Message)
 }
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// SizeMismatchError represents a download whose size differs from the reported file size
+type SizeMismatchError struct {
+	Expected int64
+	Actual   int64
+}
+
+func (e *SizeMismatchError) Error() string {
+	return fmt.Sprintf("downloaded %d bytes, expected about %d", e.Actual, e.Expected)
+}
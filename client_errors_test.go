@@ -0,0 +1,102 @@
+package gospiget
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		switch r.URL.Path {
+		case "/resources/404":
+			w.WriteHeader(http.StatusNotFound)
+		case "/resources/429":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/resources/500":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(strings.Repeat("x", 1000)))
+		case "/resources/slow":
+			time.Sleep(200 * time.Millisecond)
+		default:
+			w.Write([]byte(`not json`))
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL))
+
+	_, err := c.GetResourceByID(404)
+	assert.ErrorIs(t, err, ErrNotFound)
+	var notFound *NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Equal(t, http.MethodGet, notFound.Method)
+	assert.Equal(t, server.URL+"/resources/404", notFound.URL)
+	assert.Equal(t, "req-123", notFound.RequestID)
+	assert.Contains(t, err.Error(), "/resources/404")
+
+	_, err = get[*Resource](context.Background(), c, "/resources/429", nil, lookup("resource", 0))
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.NotErrorIs(t, err, ErrServerError)
+
+	_, err = get[*Resource](context.Background(), c, "/resources/500", nil, lookup("resource", 0))
+	assert.ErrorIs(t, err, ErrServerError)
+	var statusErr *UnexpectedStatusCodeError
+	assert.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusInternalServerError, statusErr.StatusCode)
+	assert.Len(t, statusErr.Body, maxBodySnippet)
+
+	_, err = get[*Resource](context.Background(), c, "/resources/1", nil, lookup("resource", 0))
+	var unmarshalErr *UnmarshalError
+	assert.ErrorAs(t, err, &unmarshalErr)
+	assert.Equal(t, "not json", unmarshalErr.Body)
+	assert.NotNil(t, errors.Unwrap(err))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = get[*Resource](ctx, c, "/resources/slow", nil, lookup("resource", 0))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestDeleteWebhookRedactsSecret(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	err := NewClient(WithBaseURL(server.URL)).DeleteWebhook(WebhookRegistration{ID: "abc", Secret: "s3cret"})
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "s3cret")
+}
+
+func TestNotFoundOnListEndpoints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL))
+	calls := []struct {
+		entity string
+		call   func() error
+	}{
+		{"resource", func() error { _, err := c.GetResourceVersions(99, nil); return err }},
+		{"resource", func() error { _, err := c.GetResourceUpdates(99, nil); return err }},
+		{"resource", func() error { _, err := c.GetResourceReviews(99, nil); return err }},
+		{"author", func() error { _, err := c.GetAuthorResources(99, nil); return err }},
+		{"category", func() error { _, err := c.GetCategoryResources(99, nil); return err }},
+	}
+	for _, tc := range calls {
+		var notFound *NotFoundError
+		assert.ErrorAs(t, tc.call(), &notFound)
+		assert.Equal(t, tc.entity, notFound.Entity)
+		assert.Equal(t, "99", notFound.ID)
+	}
+}
@@ -1,7 +1,14 @@
 package gospiget
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -22,6 +29,30 @@ func TestClient(t *testing.T) {
 	assert.NotEmpty(t, resources)
 	t.Log("Resources:", resources)
 
+	// Test GetNewResources
+	newResources, err := c.GetNewResources(params)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, newResources)
+	t.Log("New Resources:", newResources)
+
+	// Test GetFreeResources
+	freeResources, err := c.GetFreeResources(params)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, freeResources)
+	t.Log("Free Resources:", freeResources)
+
+	// Test GetPremiumResources
+	premiumResources, err := c.GetPremiumResources(params)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, premiumResources)
+	t.Log("Premium Resources:", premiumResources)
+
+	// Test GetResourcesForVersions
+	forVersions, err := c.GetResourcesForVersions([]string{"1.20.4", "1.21"}, MatchAll, params)
+	assert.NoError(t, err)
+	assert.NotNil(t, forVersions)
+	t.Log("Resources For Versions:", forVersions)
+
 	if len(resources) > 0 {
 		resourceID := resources[len(resources)-1].ID
 
@@ -138,3 +169,114 @@ func TestClient(t *testing.T) {
 	assert.NotEmpty(t, searchAuthors)
 	t.Log("Search Authors:", searchAuthors)
 }
+
+func TestGetNewResourcesSince(t *testing.T) {
+	now := time.Now().Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/resources/new", r.URL.Path)
+		assert.Equal(t, "-releaseDate", r.URL.Query().Get("sort"))
+		if r.URL.Query().Get("page") != "1" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		fmt.Fprintf(w, `[{"id": 3, "releaseDate": %d}, {"id": 2, "releaseDate": %d}, {"id": 1, "releaseDate": %d}]`,
+			now-60, now-3600, now-30*24*3600)
+	}))
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL))
+	resources, err := c.GetNewResourcesSince(context.Background(), time.Now().Add(-7*24*time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, resources, 2)
+	assert.Equal(t, 3, resources[0].ID)
+	assert.Equal(t, 2, resources[1].ID)
+}
+
+func TestGetResourcesForVersions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/resources/for/1.20.4,1.21", r.URL.Path)
+		assert.Equal(t, "all", r.URL.Query().Get("method"))
+		assert.Equal(t, "10", r.URL.Query().Get("size"))
+		w.Write([]byte(`{"check": ["1.20.4", "1.21"], "method": "all", "match": [{"id": 1, "testedVersions": ["1.20.4", "1.21"]}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL))
+	result, err := c.GetResourcesForVersions([]string{"1.20.4", "1.21"}, MatchAll, map[string]string{"size": "10"})
+	assert.NoError(t, err)
+	assert.Equal(t, MatchAll, result.Method)
+	assert.Equal(t, []string{"1.20.4", "1.21"}, result.Check)
+	assert.Len(t, result.Match, 1)
+	assert.Equal(t, 1, result.Match[0].ID)
+}
+
+func TestGetResourcesForVersionsDefaults(t *testing.T) {
+	var method string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.URL.Query().Get("method")
+		w.Write([]byte(`{"check": ["1.21"], "method": "any", "match": []}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL))
+	_, err := c.GetResourcesForVersions([]string{"1.21"}, "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "any", method)
+
+	_, err = c.GetResourcesForVersions(nil, MatchAll, nil)
+	assert.Error(t, err)
+}
+
+func TestGetStatus(t *testing.T) {
+	end := time.Now().Add(-2 * time.Hour)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{
+			"status": {
+				"server": {"name": "spiget-1"},
+				"fetch": {"start": %d, "end": %d, "active": false, "page": {"amount": 5, "index": 4, "item": {"index": 12}}}
+			},
+			"stats": {"resources": 100, "authors": 40, "categories": 12, "resource_updates": 300, "resource_versions": 250}
+		}`, end.Add(-time.Hour).UnixMilli(), end.UnixMilli())
+	}))
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL))
+	status, err := c.GetStatus()
+	assert.NoError(t, err)
+	assert.Equal(t, "spiget-1", status.Status.Server.Name)
+	assert.Equal(t, 5, status.Status.Fetch.Page.Amount)
+	assert.Equal(t, 12, status.Status.Fetch.Page.Item.Index)
+	assert.Equal(t, 300, status.Stats.ResourceUpdates)
+	assert.Equal(t, end.UnixMilli(), status.LastFetch().UnixMilli())
+	assert.True(t, status.IsStale(time.Hour))
+	assert.False(t, status.IsStale(3*time.Hour))
+}
+
+func TestDownloadResourceVersionChecksSize(t *testing.T) {
+	body := make([]byte, 2048)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/resources/1":
+			w.Write([]byte(`{"id": 1, "file": {"type": ".jar", "size": 2, "sizeUnit": "KB"}, "version": {"id": 5}}`))
+		case "/resources/1/versions/5/download":
+			w.Write(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL))
+	path := filepath.Join(t.TempDir(), "plugin.jar")
+	assert.NoError(t, c.DownloadResourceVersion(ResourceVersion{BaseModel: BaseModel{ID: 5}, ResourceId: 1}, path, false))
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2048), info.Size())
+
+	body = []byte("<html>blocked</html>")
+	path = filepath.Join(t.TempDir(), "plugin.jar")
+	err = c.DownloadResourceVersion(ResourceVersion{BaseModel: BaseModel{ID: 5}, ResourceId: 1}, path, false)
+	assert.Equal(t, &SizeMismatchError{Expected: 2048, Actual: int64(len(body))}, err)
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,94 @@
+package gospiget
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Endpoints that are used by more than one public method. Each builds its path
+// and not-found descriptor in one place; noCache bypasses the response cache
+// for callers that poll for changes.
+
+func (c *Client) status(ctx context.Context, noCache bool) (*Status, error) {
+	status, _, err := do[*Status](ctx, c, request{
+		method:   http.MethodGet,
+		path:     "/status",
+		noCache:  noCache,
+		notFound: notFound{message: "status not found"},
+	})
+	return status, err
+}
+
+func (c *Client) newResources(ctx context.Context, params map[string]string) ([]Resource, error) {
+	return get[[]Resource](ctx, c, "/resources/new", params, notFound{message: "new resources not found"})
+}
+
+func (c *Client) resource(ctx context.Context, resourceID int, fields []string) (*Resource, error) {
+	return get[*Resource](ctx, c, fmt.Sprintf("/resources/%d", resourceID), fieldParams(fields), lookup("resource", resourceID))
+}
+
+func (c *Client) resourceVersions(ctx context.Context, resourceID int, params map[string]string) ([]ResourceVersion, error) {
+	return get[[]ResourceVersion](ctx, c, fmt.Sprintf("/resources/%d/versions", resourceID), params, lookup("resource", resourceID))
+}
+
+func (c *Client) resourceVersion(ctx context.Context, resourceID, versionID int, fields []string) (*ResourceVersion, error) {
+	return get[*ResourceVersion](ctx, c, fmt.Sprintf("/resources/%d/versions/%d", resourceID, versionID), fieldParams(fields), lookup("resource version", versionID))
+}
+
+func (c *Client) latestVersion(ctx context.Context, resourceID int, fields []string, noCache bool) (*ResourceVersion, error) {
+	version, _, err := do[*ResourceVersion](ctx, c, request{
+		method:   http.MethodGet,
+		path:     fmt.Sprintf("/resources/%d/versions/latest", resourceID),
+		params:   fieldParams(fields),
+		noCache:  noCache,
+		notFound: notFound{"latest resource version not found", "resource", strconv.Itoa(resourceID)},
+	})
+	return version, err
+}
+
+func (c *Client) latestUpdate(ctx context.Context, resourceID int, fields []string, noCache bool) (*ResourceUpdate, error) {
+	update, _, err := do[*ResourceUpdate](ctx, c, request{
+		method:   http.MethodGet,
+		path:     fmt.Sprintf("/resources/%d/updates/latest", resourceID),
+		params:   fieldParams(fields),
+		noCache:  noCache,
+		notFound: notFound{"latest resource update not found", "resource", strconv.Itoa(resourceID)},
+	})
+	return update, err
+}
+
+func (c *Client) resourceReviews(ctx context.Context, resourceID int, params map[string]string) ([]ResourceReview, error) {
+	return get[[]ResourceReview](ctx, c, fmt.Sprintf("/resources/%d/reviews", resourceID), params, lookup("resource", resourceID))
+}
+
+func (c *Client) author(ctx context.Context, authorID int, fields []string) (*Author, error) {
+	return get[*Author](ctx, c, fmt.Sprintf("/authors/%d", authorID), fieldParams(fields), lookup("author", authorID))
+}
+
+func (c *Client) authorResources(ctx context.Context, authorID int, params map[string]string, noCache bool) ([]Resource, error) {
+	resources, _, err := do[[]Resource](ctx, c, request{
+		method:   http.MethodGet,
+		path:     fmt.Sprintf("/authors/%d/resources", authorID),
+		params:   params,
+		noCache:  noCache,
+		notFound: lookup("author", authorID),
+	})
+	return resources, err
+}
+
+func (c *Client) category(ctx context.Context, categoryID int, fields []string) (*Category, error) {
+	return get[*Category](ctx, c, fmt.Sprintf("/categories/%d", categoryID), fieldParams(fields), lookup("category", categoryID))
+}
+
+func (c *Client) categoryResources(ctx context.Context, categoryID int, params map[string]string, noCache bool) ([]Resource, error) {
+	resources, _, err := do[[]Resource](ctx, c, request{
+		method:   http.MethodGet,
+		path:     fmt.Sprintf("/categories/%d/resources", categoryID),
+		params:   params,
+		noCache:  noCache,
+		notFound: lookup("category", categoryID),
+	})
+	return resources, err
+}
@@ -0,0 +1,108 @@
+package gospiget
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+)
+
+// Expand selects the related entities GetResourceExpanded fetches
+type Expand struct {
+	Author       bool
+	Category     bool
+	Versions     bool
+	LatestUpdate bool
+	Reviews      bool
+}
+
+// ExpandAll fetches every related entity
+var ExpandAll = Expand{Author: true, Category: true, Versions: true, LatestUpdate: true, Reviews: true}
+
+// ResourceDetails is a resource together with its related entities.
+// Entities that were not requested are left nil.
+type ResourceDetails struct {
+	Resource     *Resource
+	Author       *Author
+	Category     *Category
+	Versions     []ResourceVersion
+	LatestUpdate *ResourceUpdate
+	Reviews      []ResourceReview
+}
+
+// listAllParams requests a whole list in one page, sized by the resource's reference count
+func listAllParams(references []IdReference) map[string]string {
+	if len(references) == 0 {
+		return nil
+	}
+	return map[string]string{"size": strconv.Itoa(len(references)), "sort": "-id"}
+}
+
+// GetResourceExpanded fetches a resource and then the selected related
+// entities concurrently. A resource without updates has a nil LatestUpdate.
+func (c *Client) GetResourceExpanded(ctx context.Context, resourceID int, expand Expand) (*ResourceDetails, error) {
+	resource, err := c.resource(ctx, resourceID, nil)
+	if err != nil {
+		return nil, err
+	}
+	details := &ResourceDetails{Resource: resource}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	fetch := func(fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	if expand.Author {
+		fetch(func() (err error) {
+			details.Author, err = c.author(ctx, resource.Author.ID, nil)
+			return err
+		})
+	}
+	if expand.Category {
+		fetch(func() (err error) {
+			details.Category, err = c.category(ctx, resource.Category.ID, nil)
+			return err
+		})
+	}
+	if expand.Versions {
+		fetch(func() (err error) {
+			details.Versions, err = c.resourceVersions(ctx, resourceID, listAllParams(resource.Versions))
+			return err
+		})
+	}
+	if expand.LatestUpdate {
+		fetch(func() error {
+			update, err := c.latestUpdate(ctx, resourceID, nil, false)
+			var missing *NotFoundError
+			if errors.As(err, &missing) {
+				return nil
+			}
+			details.LatestUpdate = update
+			return err
+		})
+	}
+	if expand.Reviews {
+		fetch(func() (err error) {
+			details.Reviews, err = c.resourceReviews(ctx, resourceID, listAllParams(resource.Reviews))
+			return err
+		})
+	}
+
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return details, nil
+}
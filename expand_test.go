@@ -0,0 +1,53 @@
+package gospiget
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResourceExpanded(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/resources/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "name": "Example", "author": {"id": 7}, "category": {"id": 3}, "versions": [{"id": 10}, {"id": 11}], "reviews": [{"id": 20}]}`))
+	})
+	mux.HandleFunc("/authors/7", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 7, "name": "Notch"}`))
+	})
+	mux.HandleFunc("/categories/3", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 3, "name": "Tools"}`))
+	})
+	mux.HandleFunc("/resources/1/versions", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2", r.URL.Query().Get("size"))
+		w.Write([]byte(`[{"id": 11, "name": "1.1"}, {"id": 10, "name": "1.0"}]`))
+	})
+	mux.HandleFunc("/resources/1/updates/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/resources/1/reviews", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"message": "", "version": "1.1"}]`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL))
+	details, err := c.GetResourceExpanded(context.Background(), 1, ExpandAll)
+	assert.NoError(t, err)
+	assert.Equal(t, "Example", details.Resource.Name)
+	assert.Equal(t, "Notch", details.Author.Name)
+	assert.Equal(t, "Tools", details.Category.Name)
+	assert.Len(t, details.Versions, 2)
+	assert.Nil(t, details.LatestUpdate)
+	assert.Len(t, details.Reviews, 1)
+
+	details, err = c.GetResourceExpanded(context.Background(), 1, Expand{Category: true})
+	assert.NoError(t, err)
+	assert.Nil(t, details.Author)
+	assert.NotNil(t, details.Category)
+
+	_, err = c.GetResourceExpanded(context.Background(), 2, ExpandAll)
+	assert.IsType(t, &NotFoundError{}, err)
+}
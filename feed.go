@@ -0,0 +1,244 @@
+package gospiget
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFeedPageSize = 50
+	defaultFeedMaxPages = 5
+)
+
+// FeedEvent is emitted by a FeedWatcher for a new or updated resource
+type FeedEvent struct {
+	Resource Resource
+	// New is true when the resource was released after the last checkpoint
+	New bool
+}
+
+// FeedCheckpoint holds the newest UpdateDate seen per category and author.
+// It is plain JSON so a restarted process can resume from it.
+type FeedCheckpoint struct {
+	Categories map[int]Timestamp `json:"categories"`
+	Authors    map[int]Timestamp `json:"authors"`
+}
+
+// FeedStore persists the checkpoint of a FeedWatcher between runs
+type FeedStore interface {
+	Load() (FeedCheckpoint, error)
+	Save(checkpoint FeedCheckpoint) error
+}
+
+// FileFeedStore keeps the feed checkpoint in a JSON file
+type FileFeedStore struct {
+	Path string
+}
+
+func (s *FileFeedStore) Load() (FeedCheckpoint, error) {
+	var checkpoint FeedCheckpoint
+	err := readJSONFile(s.Path, &checkpoint)
+	return checkpoint, err
+}
+
+func (s *FileFeedStore) Save(checkpoint FeedCheckpoint) error {
+	return writeJSONFile(s.Path, checkpoint)
+}
+
+// FeedWatcherOptions configures a FeedWatcher
+type FeedWatcherOptions struct {
+	// Categories and Authors are the IDs to follow
+	Categories []int
+	Authors    []int
+	// Interval between polls of all feeds. Defaults to 10 minutes.
+	Interval time.Duration
+	// Jitter randomises each interval by up to this fraction. Defaults to 0.1.
+	Jitter float64
+	// MinRequestInterval is the minimum delay between two API requests. Defaults to 1 second.
+	MinRequestInterval time.Duration
+	// PageSize is the number of resources requested per page. Defaults to 50.
+	PageSize int
+	// MaxPages bounds how far back a single poll pages through a feed. Defaults to 5.
+	MaxPages int
+	// Store persists the checkpoint. It is kept in memory when nil.
+	Store FeedStore
+	// OnError is called for failed polls and store errors. Errors are dropped when nil.
+	OnError func(err error)
+}
+
+// FeedWatcher follows categories and authors and emits a FeedEvent for every
+// resource whose UpdateDate moved past the checkpoint. The first poll of a
+// feed without a checkpoint only records a baseline.
+type FeedWatcher struct {
+	client   *Client
+	opts     FeedWatcherOptions
+	schedule *pollSchedule
+	events   chan FeedEvent
+
+	mu         sync.Mutex
+	checkpoint FeedCheckpoint
+	// reported holds the UpdateDate last emitted per resource, so a resource
+	// that appears in several feeds is only reported once per change
+	reported map[int]Timestamp
+}
+
+// NewFeedWatcher creates a FeedWatcher
+func NewFeedWatcher(client *Client, opts FeedWatcherOptions) *FeedWatcher {
+	if opts.PageSize <= 0 {
+		opts.PageSize = defaultFeedPageSize
+	}
+	if opts.MaxPages <= 0 {
+		opts.MaxPages = defaultFeedMaxPages
+	}
+	return &FeedWatcher{
+		client:   client,
+		opts:     opts,
+		schedule: newPollSchedule(opts.Interval, opts.Jitter, opts.MinRequestInterval),
+		events:   make(chan FeedEvent, 16),
+		checkpoint: FeedCheckpoint{
+			Categories: map[int]Timestamp{},
+			Authors:    map[int]Timestamp{},
+		},
+		reported: map[int]Timestamp{},
+	}
+}
+
+// Events returns the channel events are delivered on. It is closed when Run returns.
+func (f *FeedWatcher) Events() <-chan FeedEvent {
+	return f.events
+}
+
+// Checkpoint returns a copy of the current checkpoint
+func (f *FeedWatcher) Checkpoint() FeedCheckpoint {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	checkpoint := FeedCheckpoint{
+		Categories: make(map[int]Timestamp, len(f.checkpoint.Categories)),
+		Authors:    make(map[int]Timestamp, len(f.checkpoint.Authors)),
+	}
+	for id, date := range f.checkpoint.Categories {
+		checkpoint.Categories[id] = date
+	}
+	for id, date := range f.checkpoint.Authors {
+		checkpoint.Authors[id] = date
+	}
+	return checkpoint
+}
+
+// Run polls until ctx is cancelled. It returns ctx.Err() on shutdown or the
+// error from loading the store.
+func (f *FeedWatcher) Run(ctx context.Context) error {
+	defer close(f.events)
+
+	if f.opts.Store != nil {
+		checkpoint, err := f.opts.Store.Load()
+		if err != nil {
+			return err
+		}
+		f.mu.Lock()
+		for id, date := range checkpoint.Categories {
+			f.checkpoint.Categories[id] = date
+		}
+		for id, date := range checkpoint.Authors {
+			f.checkpoint.Authors[id] = date
+		}
+		f.mu.Unlock()
+	}
+
+	for {
+		for _, id := range f.opts.Categories {
+			if err := f.poll(ctx, f.checkpoint.Categories, id, f.client.categoryResources); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				f.reportError(err)
+			}
+		}
+		for _, id := range f.opts.Authors {
+			if err := f.poll(ctx, f.checkpoint.Authors, id, f.client.authorResources); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				f.reportError(err)
+			}
+		}
+
+		if f.opts.Store != nil {
+			if err := f.opts.Store.Save(f.Checkpoint()); err != nil {
+				f.reportError(err)
+			}
+		}
+
+		if err := f.schedule.waitRound(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// poll pages through one feed sorted by -updateDate until it reaches resources
+// at or before the checkpoint, then moves the checkpoint forward
+func (f *FeedWatcher) poll(ctx context.Context, marks map[int]Timestamp, id int, list func(context.Context, int, map[string]string, bool) ([]Resource, error)) error {
+	f.mu.Lock()
+	mark, known := marks[id]
+	f.mu.Unlock()
+
+	var changed []Resource
+	newest := mark
+pages:
+	for page := 1; page <= f.opts.MaxPages; page++ {
+		if err := f.schedule.waitRequest(ctx); err != nil {
+			return err
+		}
+		resources, err := list(ctx, id, map[string]string{
+			"size": strconv.Itoa(f.opts.PageSize),
+			"page": strconv.Itoa(page),
+			"sort": "-updateDate",
+		}, true)
+		if err != nil {
+			return err
+		}
+		for _, resource := range resources {
+			if resource.UpdateDate > newest {
+				newest = resource.UpdateDate
+			}
+			if resource.UpdateDate <= mark {
+				break pages
+			}
+			changed = append(changed, resource)
+		}
+		// Without a checkpoint only the newest page is needed for the baseline
+		if !known || len(resources) < f.opts.PageSize {
+			break
+		}
+	}
+
+	f.mu.Lock()
+	marks[id] = newest
+	f.mu.Unlock()
+
+	if !known {
+		return nil
+	}
+	// Emit oldest first so consumers see changes in the order they happened
+	for i := len(changed) - 1; i >= 0; i-- {
+		resource := changed[i]
+		if f.reported[resource.ID] >= resource.UpdateDate {
+			continue
+		}
+		f.reported[resource.ID] = resource.UpdateDate
+		select {
+		case f.events <- FeedEvent{Resource: resource, New: resource.ReleaseDate > mark}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (f *FeedWatcher) reportError(err error) {
+	if f.opts.OnError != nil {
+		f.opts.OnError(err)
+	}
+}
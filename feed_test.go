@@ -0,0 +1,98 @@
+package gospiget
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedWatcher(t *testing.T) {
+	var categoryPolls int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/categories/5/resources", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "-updateDate", r.URL.Query().Get("sort"))
+		if atomic.AddInt32(&categoryPolls, 1) == 1 {
+			w.Write([]byte(`[{"id": 1, "releaseDate": 50, "updateDate": 100}]`))
+			return
+		}
+		w.Write([]byte(`[{"id": 2, "releaseDate": 300, "updateDate": 300}, {"id": 1, "releaseDate": 50, "updateDate": 200}, {"id": 3, "releaseDate": 10, "updateDate": 90}]`))
+	})
+	mux.HandleFunc("/authors/9/resources", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id": 2, "releaseDate": 300, "updateDate": 300}]`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	store := &FileFeedStore{Path: filepath.Join(t.TempDir(), "feed.json")}
+	// The author feed resumes from a stored checkpoint, so its first poll already reports
+	assert.NoError(t, store.Save(FeedCheckpoint{Authors: map[int]Timestamp{9: 250}}))
+
+	// Polls must not be answered from the response cache
+	client := NewClient(WithBaseURL(server.URL), WithCache(NewMemoryCache(), 0))
+	watcher := NewFeedWatcher(client, FeedWatcherOptions{
+		Categories:         []int{5},
+		Authors:            []int{9},
+		Interval:           10 * time.Millisecond,
+		MinRequestInterval: time.Millisecond,
+		Store:              store,
+		OnError:            func(err error) { t.Error(err) },
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- watcher.Run(ctx) }()
+
+	first := <-watcher.Events()
+	assert.Equal(t, 2, first.Resource.ID)
+	assert.True(t, first.New)
+
+	second := <-watcher.Events()
+	assert.Equal(t, 1, second.Resource.ID)
+	assert.False(t, second.New)
+
+	// Resource 2 was already reported through the author feed
+	select {
+	case event := <-watcher.Events():
+		assert.NotEqual(t, 2, event.Resource.ID)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	checkpoint := watcher.Checkpoint()
+	assert.Equal(t, Timestamp(300), checkpoint.Categories[5])
+	assert.Equal(t, Timestamp(300), checkpoint.Authors[9])
+}
+
+func TestFeedWatcherCancelsRequest(t *testing.T) {
+	cancelled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(cancelled)
+	}))
+	defer server.Close()
+
+	watcher := NewFeedWatcher(NewClient(WithBaseURL(server.URL)), FeedWatcherOptions{
+		Categories:         []int{5},
+		MinRequestInterval: time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, watcher.Run(ctx), context.DeadlineExceeded)
+
+	// Shutdown aborts the in-flight request instead of waiting for the client timeout
+	select {
+	case <-cancelled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("request was not cancelled")
+	}
+}
@@ -0,0 +1,71 @@
+package gospiget
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// Params holds query parameters for list endpoints. It can be passed
+// anywhere a map[string]string of parameters is accepted.
+type Params map[string]string
+
+// Fields restricts the response to the given fields, e.g. Fields("id", "name")
+func Fields(fields ...string) Params {
+	return Params{}.Fields(fields...)
+}
+
+// Fields returns a copy of p that restricts the response to the given fields
+func (p Params) Fields(fields ...string) Params {
+	params := make(Params, len(p)+1)
+	for k, v := range p {
+		params[k] = v
+	}
+	params["fields"] = strings.Join(fields, ",")
+	return params
+}
+
+// fieldParams builds the query parameters for the optional fields of get calls
+func fieldParams(fields []string) map[string]string {
+	if len(fields) == 0 {
+		return nil
+	}
+	return map[string]string{"fields": strings.Join(fields, ",")}
+}
+
+// UnmarshalJSON decodes a resource and records which fields the response contained
+func (r *Resource) UnmarshalJSON(data []byte) error {
+	type resource Resource
+	var decoded resource
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*r = Resource(decoded)
+	r.populated = make(map[string]bool, len(raw))
+	for field := range raw {
+		r.populated[field] = true
+	}
+	return nil
+}
+
+// HasField reports whether the response contained the given JSON field, so a
+// zero value can be told apart from a field that was not requested. It always
+// returns false for resources that were not decoded from a response.
+func (r *Resource) HasField(field string) bool {
+	return r.populated[field]
+}
+
+// PopulatedFields returns the sorted JSON fields the response contained
+func (r *Resource) PopulatedFields() []string {
+	fields := make([]string, 0, len(r.populated))
+	for field := range r.populated {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
@@ -0,0 +1,43 @@
+package gospiget
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldSelection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "id,name,downloads", r.URL.Query().Get("fields"))
+		if r.URL.Path == "/resources" {
+			assert.Equal(t, "10", r.URL.Query().Get("size"))
+			w.Write([]byte(`[{"id": 1, "name": "Example", "downloads": 0}]`))
+			return
+		}
+		w.Write([]byte(`{"id": 1, "name": "Example"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL))
+
+	resource, err := c.GetResourceByID(1, "id", "name", "downloads")
+	assert.NoError(t, err)
+	assert.True(t, resource.HasField("name"))
+	assert.False(t, resource.HasField("downloads"))
+	assert.Equal(t, []string{"id", "name"}, resource.PopulatedFields())
+
+	resources, err := c.GetResources(Params{"size": "10"}.Fields("id", "name", "downloads"))
+	assert.NoError(t, err)
+	assert.True(t, resources[0].HasField("downloads"))
+	assert.Equal(t, 0, resources[0].Downloads)
+}
+
+func TestFieldsDoesNotModifyParams(t *testing.T) {
+	params := Params{"size": "10"}
+	withFields := params.Fields("id")
+	assert.Equal(t, Params{"size": "10"}, params)
+	assert.Equal(t, Params{"size": "10", "fields": "id"}, withFields)
+	assert.Equal(t, Params{"fields": "id,name"}, Fields("id", "name"))
+}
@@ -5,11 +5,11 @@ go 1.21.0
 require (
 	github.com/go-resty/resty/v2 v2.16.2
 	github.com/stretchr/testify v1.10.0
+	golang.org/x/net v0.27.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	golang.org/x/net v0.27.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
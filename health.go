@@ -0,0 +1,132 @@
+package gospiget
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultMaxLatency  = 2 * time.Second
+	defaultMaxFetchAge = 24 * time.Hour
+)
+
+// HealthState is the overall verdict of a health check
+type HealthState string
+
+// Health check verdicts
+const (
+	Healthy  HealthState = "healthy"
+	Degraded HealthState = "degraded"
+	Down     HealthState = "down"
+)
+
+// HealthOptions sets the thresholds above which the API is considered degraded
+type HealthOptions struct {
+	// MaxLatency is the slowest acceptable status request. Defaults to 2 seconds.
+	MaxLatency time.Duration
+	// MaxFetchAge is the oldest acceptable fetcher run. Defaults to 24 hours.
+	MaxFetchAge time.Duration
+}
+
+// Health is the result of a health check. In JSON the latency is given in
+// milliseconds and an unknown last fetch is omitted.
+type Health struct {
+	State     HealthState
+	Latency   time.Duration
+	LastFetch time.Time
+	Reasons   []string
+}
+
+// healthJSON is the JSON form of Health
+type healthJSON struct {
+	State     HealthState `json:"state"`
+	LatencyMs float64     `json:"latencyMs"`
+	LastFetch *time.Time  `json:"lastFetch,omitempty"`
+	Reasons   []string    `json:"reasons,omitempty"`
+}
+
+func (h Health) MarshalJSON() ([]byte, error) {
+	out := healthJSON{
+		State:     h.State,
+		LatencyMs: float64(h.Latency) / float64(time.Millisecond),
+		Reasons:   h.Reasons,
+	}
+	if !h.LastFetch.IsZero() {
+		out.LastFetch = &h.LastFetch
+	}
+	return json.Marshal(out)
+}
+
+func (h *Health) UnmarshalJSON(data []byte) error {
+	var in healthJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	*h = Health{
+		State:   in.State,
+		Latency: time.Duration(in.LatencyMs * float64(time.Millisecond)),
+		Reasons: in.Reasons,
+	}
+	if in.LastFetch != nil {
+		h.LastFetch = *in.LastFetch
+	}
+	return nil
+}
+
+// ping requests the status endpoint, bypassing the response cache
+func (c *Client) ping(ctx context.Context) (*Status, time.Duration, error) {
+	start := time.Now()
+	status, err := c.status(ctx, true)
+	return status, time.Since(start), err
+}
+
+// Ping checks that the API is reachable and returns the round-trip latency
+func (c *Client) Ping(ctx context.Context) (time.Duration, error) {
+	_, latency, err := c.ping(ctx)
+	return latency, err
+}
+
+// CheckHealth combines reachability, latency and data freshness into a verdict.
+// The API is down when unreachable and degraded when slow or stale.
+func (c *Client) CheckHealth(ctx context.Context, opts HealthOptions) Health {
+	if opts.MaxLatency <= 0 {
+		opts.MaxLatency = defaultMaxLatency
+	}
+	if opts.MaxFetchAge <= 0 {
+		opts.MaxFetchAge = defaultMaxFetchAge
+	}
+
+	status, latency, err := c.ping(ctx)
+	health := Health{State: Healthy, Latency: latency}
+	if err != nil {
+		health.State = Down
+		health.Reasons = append(health.Reasons, err.Error())
+		return health
+	}
+
+	health.LastFetch = status.LastFetch()
+	if latency > opts.MaxLatency {
+		health.State = Degraded
+		health.Reasons = append(health.Reasons, "latency above "+opts.MaxLatency.String())
+	}
+	if status.IsStale(opts.MaxFetchAge) {
+		health.State = Degraded
+		health.Reasons = append(health.Reasons, "last fetch older than "+opts.MaxFetchAge.String())
+	}
+	return health
+}
+
+// HealthHandler returns an http.Handler for readiness probes. It responds with
+// the Health as JSON, using 200 when healthy or degraded and 503 when down.
+func (c *Client) HealthHandler(opts HealthOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		health := c.CheckHealth(r.Context(), opts)
+		w.Header().Set("Content-Type", "application/json")
+		if health.State == Down {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(health)
+	})
+}
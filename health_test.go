@@ -0,0 +1,73 @@
+package gospiget
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func statusServer(lastFetch time.Time) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"status": {"fetch": {"start": %d, "end": %d}}}`, lastFetch.Unix()-60, lastFetch.Unix())
+	}))
+}
+
+func TestCheckHealth(t *testing.T) {
+	fresh := statusServer(time.Now().Add(-time.Hour))
+	defer fresh.Close()
+	stale := statusServer(time.Now().Add(-48 * time.Hour))
+	defer stale.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer down.Close()
+
+	ctx := context.Background()
+	c := NewClient(WithBaseURL(fresh.URL))
+	latency, err := c.Ping(ctx)
+	assert.NoError(t, err)
+	assert.Greater(t, latency, time.Duration(0))
+	assert.Equal(t, Healthy, c.CheckHealth(ctx, HealthOptions{}).State)
+
+	health := NewClient(WithBaseURL(stale.URL)).CheckHealth(ctx, HealthOptions{})
+	assert.Equal(t, Degraded, health.State)
+	assert.Len(t, health.Reasons, 1)
+
+	health = NewClient(WithBaseURL(down.URL)).CheckHealth(ctx, HealthOptions{})
+	assert.Equal(t, Down, health.State)
+
+	// A cached status must not hide an outage
+	outage := statusServer(time.Now())
+	cached := NewClient(WithBaseURL(outage.URL), WithCache(NewMemoryCache(), time.Hour))
+	_, err = cached.GetStatus()
+	assert.NoError(t, err)
+	outage.Close()
+	assert.Equal(t, Down, cached.CheckHealth(ctx, HealthOptions{}).State)
+}
+
+func TestHealthHandler(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer down.Close()
+
+	recorder := httptest.NewRecorder()
+	NewClient(WithBaseURL(down.URL)).HealthHandler(HealthOptions{}).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	body := recorder.Body.Bytes()
+	var health Health
+	assert.NoError(t, json.Unmarshal(body, &health))
+	assert.Equal(t, Down, health.State)
+	assert.NotContains(t, string(body), "lastFetch")
+
+	data, err := json.Marshal(Health{State: Healthy, Latency: 1500 * time.Microsecond, LastFetch: time.Unix(1700000000, 0).UTC()})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"state": "healthy", "latencyMs": 1.5, "lastFetch": "2023-11-14T22:13:20Z"}`, string(data))
+}
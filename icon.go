@@ -0,0 +1,91 @@
+package gospiget
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	spigotBaseURL = "https://www.spigotmc.org/"
+	// iconCacheTTL is used for fetched icons when the client has no cache configured
+	iconCacheTTL = 24 * time.Hour
+)
+
+// IconImage is a decoded icon or avatar
+type IconImage struct {
+	Data     []byte
+	MIMEType string
+	Image    image.Image
+}
+
+func decodeIconImage(data []byte) (*IconImage, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return &IconImage{Data: data, MIMEType: http.DetectContentType(data), Image: img}, nil
+}
+
+// Decode decodes the embedded base64 thumbnail. PNG, JPEG and GIF are supported.
+func (i *Icon) Decode() (*IconImage, error) {
+	if i.Data == "" {
+		return nil, errors.New("icon has no embedded data")
+	}
+	data, err := base64.StdEncoding.DecodeString(i.Data)
+	if err != nil {
+		return nil, err
+	}
+	return decodeIconImage(data)
+}
+
+// AbsoluteURL returns the full SpigotMC URL of the icon, or "" when there is none
+func (i *Icon) AbsoluteURL() string {
+	if i.URL == "" || strings.HasPrefix(i.URL, "http://") || strings.HasPrefix(i.URL, "https://") {
+		return i.URL
+	}
+	return spigotBaseURL + strings.TrimPrefix(i.URL, "/")
+}
+
+// FetchIcon downloads the full-size icon. Downloads are kept in the client's
+// cache, or in memory when none is configured. Icons without a URL fall back
+// to the embedded thumbnail.
+func (c *Client) FetchIcon(ctx context.Context, icon *Icon) (*IconImage, error) {
+	if icon == nil {
+		return nil, errors.New("no icon")
+	}
+	url := icon.AbsoluteURL()
+	if url == "" {
+		return icon.Decode()
+	}
+
+	key := "icon:" + url
+	if data, ok, err := c.iconCache.Get(ctx, key); err == nil && ok {
+		return decodeIconImage(data)
+	}
+
+	data, _, err := do[[]byte](ctx, c, request{
+		method:   http.MethodGet,
+		path:     url,
+		icon:     true,
+		notFound: lookup("icon", url),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := decodeIconImage(data)
+	if err != nil {
+		return nil, err
+	}
+	_ = c.iconCache.Set(ctx, key, data, c.iconCacheTTL)
+	return decoded, nil
+}
@@ -0,0 +1,74 @@
+package gospiget
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testPNG(t *testing.T, size int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestIconDecode(t *testing.T) {
+	data := testPNG(t, 2)
+	icon := Icon{URL: "data/resource_icons/28/28140.jpg", Data: base64.StdEncoding.EncodeToString(data)}
+
+	decoded, err := icon.Decode()
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded.Data)
+	assert.Equal(t, "image/png", decoded.MIMEType)
+	assert.Equal(t, 2, decoded.Image.Bounds().Dx())
+
+	assert.Equal(t, "https://www.spigotmc.org/data/resource_icons/28/28140.jpg", icon.AbsoluteURL())
+	assert.Equal(t, "", (&Icon{}).AbsoluteURL())
+
+	_, err = (&Icon{}).Decode()
+	assert.Error(t, err)
+}
+
+func TestFetchIcon(t *testing.T) {
+	full := testPNG(t, 96)
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(full)
+	}))
+	defer server.Close()
+
+	c := NewClient()
+	icon := &Icon{URL: server.URL + "/data/resource_icons/1/1.png"}
+	for i := 0; i < 2; i++ {
+		decoded, err := c.FetchIcon(context.Background(), icon)
+		assert.NoError(t, err)
+		assert.Equal(t, 96, decoded.Image.Bounds().Dx())
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+	// SpigotMC's headers say nothing about the API quota
+	assert.Equal(t, -1, c.Quota().Remaining)
+
+	// Resources without an icon, or fetched without the icon field, have none
+	_, err := c.FetchIcon(context.Background(), nil)
+	assert.Error(t, err)
+
+	// Without a URL the embedded thumbnail is used
+	thumbnail := &Icon{Data: base64.StdEncoding.EncodeToString(testPNG(t, 4))}
+	decoded, err := c.FetchIcon(context.Background(), thumbnail)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, decoded.Image.Bounds().Dx())
+}
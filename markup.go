@@ -0,0 +1,318 @@
+package gospiget
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// decodeHTML decodes a base64-encoded HTML field from the API
+func decodeHTML(encoded string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func decodeText(encoded string) (string, error) {
+	markup, err := decodeHTML(encoded)
+	if err != nil {
+		return "", err
+	}
+	return htmlToText(markup), nil
+}
+
+func decodeMarkdown(encoded string) (string, error) {
+	markup, err := decodeHTML(encoded)
+	if err != nil {
+		return "", err
+	}
+	return htmlToMarkdown(markup), nil
+}
+
+// DescriptionHTML returns the decoded description HTML
+func (r *Resource) DescriptionHTML() (string, error) { return decodeHTML(r.Description) }
+
+// DescriptionText returns the description with all markup stripped
+func (r *Resource) DescriptionText() (string, error) { return decodeText(r.Description) }
+
+// DescriptionMarkdown returns the description converted to Markdown
+func (r *Resource) DescriptionMarkdown() (string, error) { return decodeMarkdown(r.Description) }
+
+// DocumentationHTML returns the decoded documentation HTML
+func (r *Resource) DocumentationHTML() (string, error) { return decodeHTML(r.Documentation) }
+
+// DocumentationText returns the documentation with all markup stripped
+func (r *Resource) DocumentationText() (string, error) { return decodeText(r.Documentation) }
+
+// DocumentationMarkdown returns the documentation converted to Markdown
+func (r *Resource) DocumentationMarkdown() (string, error) { return decodeMarkdown(r.Documentation) }
+
+// DescriptionHTML returns the decoded update description HTML
+func (u *ResourceUpdate) DescriptionHTML() (string, error) { return decodeHTML(u.Description) }
+
+// DescriptionText returns the update description with all markup stripped
+func (u *ResourceUpdate) DescriptionText() (string, error) { return decodeText(u.Description) }
+
+// DescriptionMarkdown returns the update description converted to Markdown
+func (u *ResourceUpdate) DescriptionMarkdown() (string, error) { return decodeMarkdown(u.Description) }
+
+// MessageHTML returns the decoded review message HTML
+func (r *ResourceReview) MessageHTML() (string, error) { return decodeHTML(r.Message) }
+
+// MessageText returns the review message with all markup stripped
+func (r *ResourceReview) MessageText() (string, error) { return decodeText(r.Message) }
+
+// MessageMarkdown returns the review message converted to Markdown
+func (r *ResourceReview) MessageMarkdown() (string, error) { return decodeMarkdown(r.Message) }
+
+// ResponseMessageHTML returns the decoded author response HTML
+func (r *ResourceReview) ResponseMessageHTML() (string, error) { return decodeHTML(r.ResponseMessage) }
+
+// ResponseMessageText returns the author response with all markup stripped
+func (r *ResourceReview) ResponseMessageText() (string, error) { return decodeText(r.ResponseMessage) }
+
+// ResponseMessageMarkdown returns the author response converted to Markdown
+func (r *ResourceReview) ResponseMessageMarkdown() (string, error) {
+	return decodeMarkdown(r.ResponseMessage)
+}
+
+// bbcodeReplacements turn the BBCode SpigotMC leaves in some texts into HTML
+var bbcodeReplacements = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`(?is)\[url\](.*?)\[/url\]`), `<a href="$1">$1</a>`},
+	{regexp.MustCompile(`(?is)\[url=["']?(.*?)["']?\](.*?)\[/url\]`), `<a href="$1">$2</a>`},
+	{regexp.MustCompile(`(?is)\[img\](.*?)\[/img\]`), `<img src="$1">`},
+	{regexp.MustCompile(`(?i)\[(/?)(b|i|u|s)\]`), `<$1$2>`},
+	{regexp.MustCompile(`(?i)\[(/?)code\]`), `<${1}pre>`},
+	{regexp.MustCompile(`(?i)\[(/?)quote(=[^\]]*)?\]`), `<${1}blockquote>`},
+	{regexp.MustCompile(`(?is)\[list=1\](.*?)\[/list\]`), `<ol>$1</ol>`},
+	{regexp.MustCompile(`(?is)\[list\](.*?)\[/list\]`), `<ul>$1</ul>`},
+	{regexp.MustCompile(`\[\*\]`), `<li>`},
+	{regexp.MustCompile(`(?i)\[/?(size|color|font|center|left|right|spoiler|indent)(=[^\]]*)?\]`), ``},
+}
+
+func bbcodeToHTML(s string) string {
+	for _, r := range bbcodeReplacements {
+		s = r.pattern.ReplaceAllString(s, r.replacement)
+	}
+	return s
+}
+
+var (
+	spaceRun   = regexp.MustCompile(`[ \t\r\n]+`)
+	blankLines = regexp.MustCompile(`\n{3,}`)
+)
+
+// markupWriter renders an HTML tree either as plain text or as Markdown
+type markupWriter struct {
+	b        strings.Builder
+	markdown bool
+	pre      int
+	lists    []int // item counter per open list, -1 for unordered
+}
+
+func parseMarkup(s string) []*html.Node {
+	nodes, err := html.ParseFragment(strings.NewReader(bbcodeToHTML(s)), &html.Node{Type: html.ElementNode, DataAtom: atom.Body, Data: "body"})
+	if err != nil {
+		return nil
+	}
+	return nodes
+}
+
+func htmlToText(s string) string {
+	w := &markupWriter{}
+	for _, n := range parseMarkup(s) {
+		w.node(n)
+	}
+	return w.String()
+}
+
+func htmlToMarkdown(s string) string {
+	w := &markupWriter{markdown: true}
+	for _, n := range parseMarkup(s) {
+		w.node(n)
+	}
+	return w.String()
+}
+
+func (w *markupWriter) String() string {
+	lines := strings.Split(w.b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+func (w *markupWriter) write(s string) {
+	w.b.WriteString(s)
+}
+
+// newline ends the current line unless the output already ends with one
+func (w *markupWriter) newline() {
+	out := w.b.String()
+	if len(out) > 0 && !strings.HasSuffix(out, "\n") {
+		w.write("\n")
+	}
+}
+
+func (w *markupWriter) paragraph() {
+	w.newline()
+	w.write("\n")
+}
+
+func (w *markupWriter) wrap(n *html.Node, marker string) {
+	if w.markdown {
+		w.write(marker)
+	}
+	w.children(n)
+	if w.markdown {
+		w.write(marker)
+	}
+}
+
+func (w *markupWriter) children(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w.node(c)
+	}
+}
+
+func (w *markupWriter) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		if w.pre > 0 {
+			w.write(n.Data)
+			return
+		}
+		text := spaceRun.ReplaceAllString(n.Data, " ")
+		out := w.b.String()
+		if len(out) == 0 || strings.HasSuffix(out, "\n") || strings.HasSuffix(out, " ") {
+			text = strings.TrimLeft(text, " ")
+		}
+		w.write(text)
+		return
+	case html.ElementNode:
+	default:
+		w.children(n)
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style:
+	case atom.Br:
+		w.write("\n")
+	case atom.P, atom.Div:
+		w.paragraph()
+		w.children(n)
+		w.paragraph()
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		w.paragraph()
+		if w.markdown {
+			level, _ := strconv.Atoi(n.Data[1:])
+			w.write(strings.Repeat("#", level) + " ")
+		}
+		w.children(n)
+		w.paragraph()
+	case atom.B, atom.Strong:
+		w.wrap(n, "**")
+	case atom.I, atom.Em:
+		w.wrap(n, "*")
+	case atom.S, atom.Strike, atom.Del:
+		w.wrap(n, "~~")
+	case atom.Code:
+		if w.pre > 0 {
+			w.children(n)
+			return
+		}
+		w.wrap(n, "`")
+	case atom.Pre:
+		w.paragraph()
+		if w.markdown {
+			w.write("```\n")
+		}
+		w.pre++
+		w.children(n)
+		w.pre--
+		if w.markdown {
+			w.newline()
+			w.write("```")
+		}
+		w.paragraph()
+	case atom.Blockquote:
+		w.paragraph()
+		start := w.b.Len()
+		w.children(n)
+		if w.markdown {
+			quoted := strings.TrimSpace(w.b.String()[start:])
+			rest := w.b.String()[:start]
+			w.b.Reset()
+			w.write(rest)
+			w.write("> " + strings.ReplaceAll(quoted, "\n", "\n> "))
+		}
+		w.paragraph()
+	case atom.Ul, atom.Ol:
+		// Nested lists continue their parent, top-level lists are set apart
+		if len(w.lists) == 0 {
+			w.paragraph()
+		} else {
+			w.newline()
+		}
+		if n.DataAtom == atom.Ol {
+			w.lists = append(w.lists, 0)
+		} else {
+			w.lists = append(w.lists, -1)
+		}
+		w.children(n)
+		w.lists = w.lists[:len(w.lists)-1]
+		if len(w.lists) == 0 {
+			w.paragraph()
+		} else {
+			w.newline()
+		}
+	case atom.Li:
+		w.newline()
+		depth := len(w.lists)
+		if depth > 1 {
+			w.write(strings.Repeat("  ", depth-1))
+		}
+		if depth > 0 && w.lists[depth-1] >= 0 {
+			w.lists[depth-1]++
+			w.write(strconv.Itoa(w.lists[depth-1]) + ". ")
+		} else {
+			w.write("- ")
+		}
+		w.children(n)
+		w.newline()
+	case atom.A:
+		href := attr(n, "href")
+		if !w.markdown || href == "" {
+			w.children(n)
+			return
+		}
+		w.write("[")
+		w.children(n)
+		w.write("](" + href + ")")
+	case atom.Img:
+		if w.markdown {
+			w.write("![" + attr(n, "alt") + "](" + attr(n, "src") + ")")
+		}
+	default:
+		w.children(n)
+	}
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,53 @@
+package gospiget
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encode(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+func TestResourceDescription(t *testing.T) {
+	description := `<h2>Features</h2><p>A <b>fast</b> plugin with <a href="https://example.com/docs">docs</a> &amp; more.</p>` +
+		`<ul><li>One</li><li>Two <i>items</i></li></ul><br><img src="https://example.com/banner.png" alt="banner">`
+	resource := Resource{Description: encode(description)}
+
+	decoded, err := resource.DescriptionHTML()
+	assert.NoError(t, err)
+	assert.Equal(t, description, decoded)
+
+	text, err := resource.DescriptionText()
+	assert.NoError(t, err)
+	assert.Equal(t, "Features\n\nA fast plugin with docs & more.\n\n- One\n- Two items", text)
+
+	markdown, err := resource.DescriptionMarkdown()
+	assert.NoError(t, err)
+	assert.Equal(t, "## Features\n\nA **fast** plugin with [docs](https://example.com/docs) & more.\n\n- One\n- Two *items*\n\n![banner](https://example.com/banner.png)", markdown)
+}
+
+func TestBBCodeToMarkdown(t *testing.T) {
+	update := ResourceUpdate{Description: encode(`[B]Changes[/B]<br>[LIST=1][*]Fixed [URL='https://example.com/issue']a bug[/URL][*][COLOR=red]Faster[/COLOR][/LIST][CODE]/reload[/CODE]`)}
+
+	markdown, err := update.DescriptionMarkdown()
+	assert.NoError(t, err)
+	assert.Equal(t, "**Changes**\n\n1. Fixed [a bug](https://example.com/issue)\n2. Faster\n\n```\n/reload\n```", markdown)
+}
+
+func TestReviewMessage(t *testing.T) {
+	review := ResourceReview{Message: encode("Great<br/>plugin!"), ResponseMessage: encode("<blockquote>Thanks</blockquote>")}
+
+	text, err := review.MessageText()
+	assert.NoError(t, err)
+	assert.Equal(t, "Great\nplugin!", text)
+
+	markdown, err := review.ResponseMessageMarkdown()
+	assert.NoError(t, err)
+	assert.Equal(t, "> Thanks", markdown)
+
+	_, err = (&ResourceReview{Message: "not base64!"}).MessageHTML()
+	assert.Error(t, err)
+}
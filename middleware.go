@@ -0,0 +1,42 @@
+package gospiget
+
+import "net/http"
+
+// Middleware wraps the transport that carries every request the client sends
+// to the API, including downloads. Icon fetches go to SpigotMC and use the
+// separate WithIconMiddleware chain. It can modify the request before calling
+// next, and the response or error after.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to an http.RoundTripper
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware adds middleware to the client. The first middleware added is
+// the outermost: it sees each request first and its response last. Middleware
+// runs in front of the response cache, so it also sees cached responses.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// WithIconMiddleware adds middleware for FetchIcon requests, which go to
+// SpigotMC instead of the API. It is ordered like WithMiddleware and keeps
+// API credentials added by that chain away from the icon host.
+func WithIconMiddleware(middleware ...Middleware) Option {
+	return func(c *Client) {
+		c.iconMiddleware = append(c.iconMiddleware, middleware...)
+	}
+}
+
+// chain wraps transport in the middleware, outermost first
+func chain(transport http.RoundTripper, middleware []Middleware) http.RoundTripper {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		transport = middleware[i](transport)
+	}
+	return transport
+}
@@ -0,0 +1,134 @@
+package gospiget
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/resources/1/versions/2/download":
+			w.Write([]byte("jar"))
+		default:
+			w.Write([]byte(`{"id": 1, "name": "Resource"}`))
+		}
+	}))
+	defer server.Close()
+
+	var order []string
+	record := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+" request")
+				resp, err := next.RoundTrip(req)
+				order = append(order, name+" response")
+				return resp, err
+			})
+		}
+	}
+	auth := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("Authorization", "Bearer token")
+			return next.RoundTrip(req)
+		})
+	}
+
+	c := NewClient(
+		WithBaseURL(server.URL),
+		WithCache(NewMemoryCache(), time.Minute),
+		WithMiddleware(record("outer"), record("inner")),
+		WithMiddleware(auth),
+	)
+
+	resource, err := c.GetResourceByID(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Resource", resource.Name)
+	assert.Equal(t, []string{"outer request", "inner request", "inner response", "outer response"}, order)
+
+	// Cached responses still pass through the middleware
+	order = nil
+	_, err = c.GetResourceByID(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+	assert.Len(t, order, 4)
+
+	// Downloads use the same chain
+	order = nil
+	path := filepath.Join(t.TempDir(), "resource.jar")
+	assert.NoError(t, c.DownloadResourceVersion(ResourceVersion{BaseModel: BaseModel{ID: 2}, ResourceId: 1}, path, false))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("jar"), data)
+	assert.Contains(t, order, "outer request")
+}
+
+func TestMiddlewareSkipsOtherHosts(t *testing.T) {
+	var authorization string
+	icons := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Write(testPNG(t, 8))
+	}))
+	defer icons.Close()
+
+	auth := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("Authorization", "Bearer token")
+			return next.RoundTrip(req)
+		})
+	}
+
+	c := NewClient(WithBaseURL("http://spiget.invalid"), WithMiddleware(auth))
+	_, err := c.FetchIcon(context.Background(), &Icon{URL: icons.URL + "/icon.png"})
+	assert.NoError(t, err)
+	assert.Empty(t, authorization)
+}
+
+func TestMiddlewareMutatesResponse(t *testing.T) {
+	stub := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			rec.WriteHeader(http.StatusNotFound)
+			return rec.Result(), nil
+		})
+	}
+
+	c := NewClient(WithBaseURL("http://spiget.invalid"), WithMiddleware(stub))
+	_, err := c.GetAuthorByID(7)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestIconMiddleware(t *testing.T) {
+	var apiCalls, iconCalls []string
+	record := func(calls *[]string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				*calls = append(*calls, req.URL.String())
+				rec := httptest.NewRecorder()
+				rec.Write(testPNG(t, 8))
+				return rec.Result(), nil
+			})
+		}
+	}
+
+	c := NewClient(WithMiddleware(record(&apiCalls)), WithIconMiddleware(record(&iconCalls)))
+	icon, err := c.FetchIcon(context.Background(), &Icon{URL: "data/resource_icons/1/1.png"})
+	assert.NoError(t, err)
+	assert.Equal(t, 8, icon.Image.Bounds().Dx())
+	assert.Equal(t, []string{"https://www.spigotmc.org/data/resource_icons/1/1.png"}, iconCalls)
+	assert.Empty(t, apiCalls)
+}
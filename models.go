@@ -1,5 +1,51 @@
 package gospiget
 
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Timestamp is a Unix timestamp in seconds as used by the API
+type Timestamp int64
+
+// Time returns the timestamp as a time.Time, or the zero time when unset
+func (t Timestamp) Time() time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(t), 0)
+}
+
+// NewTimestamp converts a time.Time to a Timestamp
+func NewTimestamp(t time.Time) Timestamp {
+	if t.IsZero() {
+		return 0
+	}
+	return Timestamp(t.Unix())
+}
+
+// UnmarshalJSON accepts seconds as an integer or float, and null for unset
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*t = 0
+		return nil
+	}
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err != nil {
+		return err
+	}
+	*t = Timestamp(seconds)
+	return nil
+}
+
+// MarshalJSON writes the timestamp as integer seconds
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(t), 10)), nil
+}
+
 // BaseModel contains common functionality for models
 type BaseModel struct {
 	ID int `json:"id"`
@@ -14,6 +60,29 @@ type ResourceFile struct {
 	ExternalURL string  `json:"externalUrl"`
 }
 
+// sizeUnits maps SizeUnit values to bytes. SpigotMC uses binary units.
+var sizeUnits = map[string]int64{
+	"B":  1,
+	"KB": 1 << 10,
+	"MB": 1 << 20,
+	"GB": 1 << 30,
+}
+
+// Bytes returns the file size in bytes, or 0 when the size or unit is unknown.
+// The API rounds sizes, so the actual file may differ by up to half a unit.
+func (f *ResourceFile) Bytes() int64 {
+	unit, ok := sizeUnits[strings.ToUpper(strings.TrimSpace(f.SizeUnit))]
+	if !ok || f.Size <= 0 {
+		return 0
+	}
+	return int64(math.Round(f.Size * float64(unit)))
+}
+
+// sizeTolerance is how far an actual size may be from Bytes() due to rounding
+func (f *ResourceFile) sizeTolerance() int64 {
+	return sizeUnits[strings.ToUpper(strings.TrimSpace(f.SizeUnit))] / 2
+}
+
 // Icon represents a resource icon or author avatar
 type Icon struct {
 	URL  string `json:"url"`
@@ -32,7 +101,7 @@ type ResourceVersion struct {
 	UUID        string         `json:"uuid"`
 	ResourceId  int            `json:"resource"`
 	Name        string         `json:"name"`
-	ReleaseDate int64          `json:"releaseDate"`
+	ReleaseDate Timestamp      `json:"releaseDate"`
 	Downloads   int            `json:"downloads"`
 	Rating      ResourceRating `json:"rating"`
 }
@@ -40,11 +109,11 @@ type ResourceVersion struct {
 // ResourceUpdate represents an update to a resource
 type ResourceUpdate struct {
 	BaseModel
-	Resource    int    `json:"resource"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Date        int64  `json:"date"`
-	Likes       int    `json:"likes"`
+	Resource    int       `json:"resource"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Date        Timestamp `json:"date"`
+	Likes       int       `json:"likes"`
 }
 
 // Author represents a resource author
@@ -67,7 +136,7 @@ type ResourceReview struct {
 	Message         string         `json:"message"`
 	ResponseMessage string         `json:"responseMessage"`
 	Version         string         `json:"version"`
-	Date            int64          `json:"date"`
+	Date            Timestamp      `json:"date"`
 }
 
 // IdReference represents a reference to another object by ID
@@ -92,8 +161,8 @@ type Resource struct {
 	TestedVersions []string           `json:"testedVersions"`
 	Links          map[string]string  `json:"links"`
 	Rating         *ResourceRating    `json:"rating"`
-	ReleaseDate    int64              `json:"releaseDate"`
-	UpdateDate     int64              `json:"updateDate"`
+	ReleaseDate    Timestamp          `json:"releaseDate"`
+	UpdateDate     Timestamp          `json:"updateDate"`
 	Downloads      int                `json:"downloads"`
 	External       bool               `json:"external"`
 	Icon           *Icon              `json:"icon"`
@@ -110,4 +179,95 @@ type Resource struct {
 	Reviews        []IdReference      `json:"reviews"`
 	Versions       []IdReference      `json:"versions"`
 	Updates        []IdReference      `json:"updates"`
+
+	// populated records the JSON fields present in the response
+	populated map[string]bool
+}
+
+// MatchMethod selects how GetResourcesForVersions matches tested versions
+type MatchMethod string
+
+const (
+	// MatchAny matches resources tested for at least one of the versions
+	MatchAny MatchMethod = "any"
+	// MatchAll matches resources tested for every one of the versions
+	MatchAll MatchMethod = "all"
+)
+
+// ResourcesForVersions represents the result of a tested version lookup
+type ResourcesForVersions struct {
+	Check  []string    `json:"check"`
+	Method MatchMethod `json:"method"`
+	Match  []Resource  `json:"match"`
+}
+
+// Status represents the API status returned by the status endpoint
+type Status struct {
+	Status StatusInfo  `json:"status"`
+	Stats  StatusStats `json:"stats"`
+}
+
+// StatusInfo represents the server and fetcher state
+type StatusInfo struct {
+	Server StatusServer `json:"server"`
+	Fetch  StatusFetch  `json:"fetch"`
+}
+
+// StatusServer represents the server that answered the request
+type StatusServer struct {
+	Name string `json:"name"`
+}
+
+// StatusFetch represents the progress of Spiget's SpigotMC fetcher
+type StatusFetch struct {
+	Start  int64           `json:"start"`
+	End    int64           `json:"end"`
+	Active bool            `json:"active"`
+	Page   StatusFetchPage `json:"page"`
+}
+
+// StatusFetchPage represents the fetcher's page and item counters
+type StatusFetchPage struct {
+	Amount int             `json:"amount"`
+	Index  int             `json:"index"`
+	Item   StatusFetchItem `json:"item"`
+}
+
+// StatusFetchItem represents the fetcher's position within the current page
+type StatusFetchItem struct {
+	Index int `json:"index"`
+}
+
+// StatusStats represents the number of stored entities
+type StatusStats struct {
+	Resources        int `json:"resources"`
+	Authors          int `json:"authors"`
+	Categories       int `json:"categories"`
+	ResourceUpdates  int `json:"resource_updates"`
+	ResourceVersions int `json:"resource_versions"`
+}
+
+// LastFetch returns when the last fetch finished, or when the running one started
+func (s *Status) LastFetch() time.Time {
+	if s.Status.Fetch.End != 0 {
+		return fetchTime(s.Status.Fetch.End)
+	}
+	return fetchTime(s.Status.Fetch.Start)
+}
+
+// IsStale reports whether the last fetch is older than threshold
+func (s *Status) IsStale(threshold time.Duration) bool {
+	last := s.LastFetch()
+	return last.IsZero() || time.Since(last) > threshold
+}
+
+// fetchTime converts a fetcher timestamp, which may be in seconds or milliseconds
+func fetchTime(ts int64) time.Time {
+	if ts == 0 {
+		return time.Time{}
+	}
+	if ts > 1e12 {
+		return time.UnixMilli(ts)
+	}
+	return time.Unix(ts, 0)
 }
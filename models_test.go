@@ -0,0 +1,36 @@
+package gospiget
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestamp(t *testing.T) {
+	var update ResourceUpdate
+	assert.NoError(t, json.Unmarshal([]byte(`{"id": 1, "date": 1700000000}`), &update))
+	assert.Equal(t, Timestamp(1700000000), update.Date)
+	assert.Equal(t, time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC), update.Date.Time().UTC())
+
+	data, err := json.Marshal(update)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"date":1700000000`)
+
+	var resource Resource
+	assert.NoError(t, json.Unmarshal([]byte(`{"releaseDate": 1.7e9, "updateDate": null}`), &resource))
+	assert.Equal(t, Timestamp(1700000000), resource.ReleaseDate)
+	assert.True(t, resource.UpdateDate.Time().IsZero())
+
+	now := time.Unix(time.Now().Unix(), 0)
+	assert.Equal(t, now, NewTimestamp(now).Time())
+}
+
+func TestResourceFileBytes(t *testing.T) {
+	assert.Equal(t, int64(1572864), (&ResourceFile{Size: 1.5, SizeUnit: "MB"}).Bytes())
+	assert.Equal(t, int64(153600), (&ResourceFile{Size: 150, SizeUnit: "KB"}).Bytes())
+	assert.Equal(t, int64(512), (&ResourceFile{Size: 512, SizeUnit: "b"}).Bytes())
+	assert.Equal(t, int64(0), (&ResourceFile{Size: 3, SizeUnit: ""}).Bytes())
+	assert.Equal(t, int64(0), (&ResourceFile{}).Bytes())
+}
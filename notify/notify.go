@@ -0,0 +1,121 @@
+// Package notify posts messages about Spiget resources to chat webhooks and email.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/Mark7888/gospiget"
+)
+
+// Message is a rendered notification
+type Message struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	URL   string `json:"url"`
+}
+
+// Notifier delivers a message to a destination
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// Data is what templates are rendered from. Any of the fields may be nil.
+type Data struct {
+	Resource *gospiget.Resource
+	Version  *gospiget.ResourceVersion
+	Update   *gospiget.ResourceUpdate
+}
+
+// ResourceID returns the ID of the resource the data is about
+func (d Data) ResourceID() int {
+	switch {
+	case d.Resource != nil:
+		return d.Resource.ID
+	case d.Version != nil:
+		return d.Version.ResourceId
+	case d.Update != nil:
+		return d.Update.Resource
+	}
+	return 0
+}
+
+// URL returns the SpigotMC page of the resource
+func (d Data) URL() string {
+	return fmt.Sprintf("https://www.spigotmc.org/resources/%d/", d.ResourceID())
+}
+
+// Templates render the title and body of a message
+type Templates struct {
+	Title *template.Template
+	Body  *template.Template
+}
+
+// DefaultTemplates announce versions and updates with the resource name and tag
+var DefaultTemplates = Templates{
+	Title: template.Must(template.New("title").Parse(
+		`{{with .Resource}}{{.Name}}{{else}}Resource {{.ResourceID}}{{end}}` +
+			`{{with .Version}} {{.Name}} released{{end}}` +
+			`{{with .Update}}: {{.Title}}{{end}}`)),
+	Body: template.Must(template.New("body").Parse(
+		`{{with .Resource}}{{.Tag}}
+{{end}}{{with .Version}}Version: {{.Name}}
+{{end}}{{with .Update}}Update: {{.Title}}
+{{end}}{{.URL}}`)),
+}
+
+// Render executes the templates against data
+func Render(t Templates, data Data) (Message, error) {
+	var title, body strings.Builder
+	if err := t.Title.Execute(&title, data); err != nil {
+		return Message{}, err
+	}
+	if err := t.Body.Execute(&body, data); err != nil {
+		return Message{}, err
+	}
+	return Message{
+		Title: strings.TrimSpace(title.String()),
+		Body:  strings.TrimSpace(body.String()),
+		URL:   data.URL(),
+	}, nil
+}
+
+// Send renders data with t and delivers it through n
+func Send(ctx context.Context, n Notifier, t Templates, data Data) error {
+	msg, err := Render(t, data)
+	if err != nil {
+		return err
+	}
+	return n.Notify(ctx, msg)
+}
+
+// postJSON sends payload to url and expects a 2xx response
+func postJSON(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("notify: unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
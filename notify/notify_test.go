@@ -0,0 +1,176 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/Mark7888/gospiget"
+	"github.com/stretchr/testify/assert"
+)
+
+var sampleData = Data{
+	Resource: &gospiget.Resource{BaseModel: gospiget.BaseModel{ID: 123}, Name: "ExamplePlugin", Tag: "Does things"},
+	Version:  &gospiget.ResourceVersion{BaseModel: gospiget.BaseModel{ID: 9}, ResourceId: 123, Name: "2.0.0"},
+}
+
+func TestRender(t *testing.T) {
+	msg, err := Render(DefaultTemplates, sampleData)
+	assert.NoError(t, err)
+	assert.Equal(t, "ExamplePlugin 2.0.0 released", msg.Title)
+	assert.Equal(t, "Does things\nVersion: 2.0.0\nhttps://www.spigotmc.org/resources/123/", msg.Body)
+	assert.Equal(t, "https://www.spigotmc.org/resources/123/", msg.URL)
+
+	msg, err = Render(DefaultTemplates, Data{Update: &gospiget.ResourceUpdate{Resource: 77, Title: "Bug fixes"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "Resource 77: Bug fixes", msg.Title)
+
+	custom := Templates{
+		Title: template.Must(template.New("title").Parse(`New: {{.Resource.Name}}`)),
+		Body:  DefaultTemplates.Body,
+	}
+	msg, err = Render(custom, sampleData)
+	assert.NoError(t, err)
+	assert.Equal(t, "New: ExamplePlugin", msg.Title)
+}
+
+func TestWebhookNotifiers(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		received = nil
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	msg, err := Render(DefaultTemplates, sampleData)
+	assert.NoError(t, err)
+
+	assert.NoError(t, (&WebhookNotifier{URL: server.URL}).Notify(ctx, msg))
+	assert.Equal(t, msg.Title, received["title"])
+	assert.Equal(t, msg.URL, received["url"])
+
+	assert.NoError(t, Send(ctx, &DiscordNotifier{WebhookURL: server.URL, Color: 0xE8A33D}, DefaultTemplates, sampleData))
+	embed := received["embeds"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, msg.Title, embed["title"])
+	assert.Equal(t, msg.Body, embed["description"])
+	assert.Equal(t, float64(0xE8A33D), embed["color"])
+
+	assert.NoError(t, (&SlackNotifier{WebhookURL: server.URL}).Notify(ctx, msg))
+	attachment := received["attachments"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, msg.URL, attachment["title_link"])
+	assert.Equal(t, msg.Body, attachment["text"])
+}
+
+func TestWebhookNotifierError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	err := (&WebhookNotifier{URL: server.URL}).Notify(context.Background(), Message{Title: "x"})
+	assert.Error(t, err)
+}
+
+// fakeSMTPServer accepts a single message and sends it on the returned channel
+func fakeSMTPServer(t *testing.T) (string, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	messages := make(chan string, 1)
+
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ready")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(command, "DATA"):
+				reply("354 go ahead")
+				var data strings.Builder
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				messages <- data.String()
+				reply("250 queued")
+			case strings.HasPrefix(command, "QUIT"):
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), messages
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	addr, messages := fakeSMTPServer(t)
+	notifier := &SMTPNotifier{Addr: addr, From: "spiget@example.com", To: []string{"team@example.com"}}
+
+	msg := Message{Title: "ExamplePlugin 2.0.0 released", Body: "Version: 2.0.0", URL: "https://www.spigotmc.org/resources/123/"}
+	assert.NoError(t, notifier.Notify(context.Background(), msg))
+
+	mail := <-messages
+	assert.Contains(t, mail, "Subject: ExamplePlugin 2.0.0 released\r\n")
+	assert.Contains(t, mail, "To: team@example.com\r\n")
+	assert.Contains(t, mail, "Version: 2.0.0\r\n\r\nhttps://www.spigotmc.org/resources/123/")
+
+	// Non-ASCII titles are encoded so the header stays valid without SMTPUTF8
+	addr, messages = fakeSMTPServer(t)
+	notifier.Addr = addr
+	assert.NoError(t, notifier.Notify(context.Background(), Message{Title: "Bädge Plugin 1.0 released"}))
+	mail = <-messages
+	assert.Contains(t, mail, "Subject: =?utf-8?q?B=C3=A4dge_Plugin_1.0_released?=\r\n")
+}
+
+func TestSMTPNotifierTimeout(t *testing.T) {
+	// A server that accepts the connection but never greets
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			<-done
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	notifier := &SMTPNotifier{Addr: listener.Addr().String(), From: "spiget@example.com", To: []string{"team@example.com"}}
+	start := time.Now()
+	err = notifier.Notify(ctx, Message{Title: "x"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
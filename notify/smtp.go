@@ -0,0 +1,111 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPNotifier sends the message as a plain-text email
+type SMTPNotifier struct {
+	// Addr is the host:port of the SMTP server
+	Addr string
+	// Auth is used when the server requires authentication. May be nil.
+	Auth smtp.Auth
+	From string
+	To   []string
+}
+
+func (n *SMTPNotifier) Notify(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", n.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(n.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", sanitizeHeader(msg.Title)))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	if msg.URL != "" {
+		b.WriteString("\r\n\r\n" + msg.URL)
+	}
+	b.WriteString("\r\n")
+
+	if err := n.send(ctx, []byte(b.String())); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	return nil
+}
+
+// send delivers msg like smtp.SendMail, but aborts the session when ctx is done
+func (n *SMTPNotifier) send(ctx context.Context, msg []byte) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", n.Addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	// Expire the connection deadline as soon as ctx is done, which also covers its deadline
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	host, _, err := net.SplitHostPort(n.Addr)
+	if err != nil {
+		return err
+	}
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if n.Auth != nil {
+		if ok, _ := client.Extension("AUTH"); ok {
+			if err := client.Auth(n.Auth); err != nil {
+				return err
+			}
+		}
+	}
+	if err := client.Mail(n.From); err != nil {
+		return err
+	}
+	for _, to := range n.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// sanitizeHeader keeps rendered values from injecting extra headers
+func sanitizeHeader(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
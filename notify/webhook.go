@@ -0,0 +1,79 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+)
+
+// WebhookNotifier posts the message as plain JSON ({"title", "body", "url"})
+type WebhookNotifier struct {
+	URL string
+	// Client is used for the request. http.DefaultClient is used when nil.
+	Client *http.Client
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, msg Message) error {
+	return postJSON(ctx, n.Client, n.URL, msg)
+}
+
+// DiscordNotifier posts the message as an embed to a Discord webhook
+type DiscordNotifier struct {
+	WebhookURL string
+	// Username overrides the webhook's default name when set
+	Username string
+	// Color is the embed's side color as 0xRRGGBB
+	Color  int
+	Client *http.Client
+}
+
+type discordEmbed struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Color       int    `json:"color,omitempty"`
+}
+
+type discordPayload struct {
+	Username string         `json:"username,omitempty"`
+	Embeds   []discordEmbed `json:"embeds"`
+}
+
+func (n *DiscordNotifier) Notify(ctx context.Context, msg Message) error {
+	return postJSON(ctx, n.Client, n.WebhookURL, discordPayload{
+		Username: n.Username,
+		Embeds: []discordEmbed{{
+			Title:       msg.Title,
+			Description: msg.Body,
+			URL:         msg.URL,
+			Color:       n.Color,
+		}},
+	})
+}
+
+// SlackNotifier posts the message as an attachment to a Slack incoming webhook
+type SlackNotifier struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+type slackAttachment struct {
+	Title     string `json:"title"`
+	TitleLink string `json:"title_link,omitempty"`
+	Text      string `json:"text,omitempty"`
+}
+
+type slackPayload struct {
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, msg Message) error {
+	return postJSON(ctx, n.Client, n.WebhookURL, slackPayload{
+		Text: msg.Title,
+		Attachments: []slackAttachment{{
+			Title:     msg.Title,
+			TitleLink: msg.URL,
+			Text:      msg.Body,
+		}},
+	})
+}
@@ -0,0 +1,91 @@
+package gospiget
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"os"
+	"time"
+)
+
+const (
+	defaultWatchInterval      = 10 * time.Minute
+	defaultMinRequestInterval = time.Second
+	defaultWatchJitter        = 0.1
+)
+
+// pollSchedule paces the watchers: jittered rounds and a minimum gap between requests
+type pollSchedule struct {
+	interval           time.Duration
+	jitter             float64
+	minRequestInterval time.Duration
+	lastRequest        time.Time
+}
+
+func newPollSchedule(interval time.Duration, jitter float64, minRequestInterval time.Duration) *pollSchedule {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	if jitter <= 0 {
+		jitter = defaultWatchJitter
+	}
+	if minRequestInterval <= 0 {
+		minRequestInterval = defaultMinRequestInterval
+	}
+	return &pollSchedule{interval: interval, jitter: jitter, minRequestInterval: minRequestInterval}
+}
+
+// waitRound sleeps for the interval randomised by up to ±jitter
+func (p *pollSchedule) waitRound(ctx context.Context) error {
+	jitter := (rand.Float64()*2 - 1) * p.jitter * float64(p.interval)
+	return sleep(ctx, p.interval+time.Duration(jitter))
+}
+
+// waitRequest sleeps until the minimum request interval has passed since the previous request
+func (p *pollSchedule) waitRequest(ctx context.Context) error {
+	if err := sleep(ctx, time.Until(p.lastRequest.Add(p.minRequestInterval))); err != nil {
+		return err
+	}
+	p.lastRequest = time.Now()
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// readJSONFile decodes path into v, leaving v untouched if the file does not exist
+func readJSONFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// writeJSONFile atomically replaces path with the JSON encoding of v
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,143 @@
+package gospiget
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// rateLimitWindow is how long a 429 response counts as recent
+const rateLimitWindow = 5 * time.Minute
+
+// Quota is the client's view of the API rate limit, built from response
+// headers and recent 429 responses
+type Quota struct {
+	// Limit and Remaining come from the rate-limit headers, -1 when the API did not send them
+	Limit     int
+	Remaining int
+	// Reset is when the limit resets, zero when unknown
+	Reset time.Time
+	// BlockedUntil is when the last Retry-After expires
+	BlockedUntil time.Time
+	// RecentRateLimits is the number of 429 responses in the last five minutes
+	RecentRateLimits int
+	// UpdatedAt is when the last response was seen
+	UpdatedAt time.Time
+}
+
+// Blocked reports whether the API asked the client to wait
+func (q Quota) Blocked() bool {
+	return time.Now().Before(q.BlockedUntil)
+}
+
+// rateLimitHeaders are the values parsed from a single response
+type rateLimitHeaders struct {
+	limit      int
+	remaining  int
+	reset      time.Time
+	retryAfter time.Duration
+}
+
+// firstHeader returns the first non-empty header of names
+func firstHeader(header http.Header, names ...string) string {
+	for _, name := range names {
+		if v := header.Get(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func parseRateLimitHeaders(header http.Header, now time.Time) rateLimitHeaders {
+	h := rateLimitHeaders{limit: -1, remaining: -1}
+	if v, err := strconv.Atoi(firstHeader(header, "X-RateLimit-Limit", "RateLimit-Limit")); err == nil {
+		h.limit = v
+	}
+	if v, err := strconv.Atoi(firstHeader(header, "X-RateLimit-Remaining", "RateLimit-Remaining")); err == nil {
+		h.remaining = v
+	}
+	// Reset is either a Unix timestamp or a number of seconds from now
+	if v, err := strconv.ParseInt(firstHeader(header, "X-RateLimit-Reset", "RateLimit-Reset"), 10, 64); err == nil {
+		if v > 1e9 {
+			h.reset = time.Unix(v, 0)
+		} else {
+			h.reset = now.Add(time.Duration(v) * time.Second)
+		}
+	}
+	// Retry-After is either a number of seconds or an HTTP date
+	if v := header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil {
+			h.retryAfter = time.Duration(seconds) * time.Second
+		} else if date, err := http.ParseTime(v); err == nil {
+			h.retryAfter = date.Sub(now)
+		}
+	}
+	return h
+}
+
+// quotaTracker records rate-limit information from every response
+type quotaTracker struct {
+	mu          sync.Mutex
+	quota       Quota
+	rateLimited []time.Time
+}
+
+func newQuotaTracker() *quotaTracker {
+	return &quotaTracker{quota: Quota{Limit: -1, Remaining: -1}}
+}
+
+func (t *quotaTracker) observe(statusCode int, header http.Header) {
+	now := time.Now()
+	h := parseRateLimitHeaders(header, now)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.quota.UpdatedAt = now
+	if h.limit >= 0 {
+		t.quota.Limit = h.limit
+	}
+	if h.remaining >= 0 {
+		t.quota.Remaining = h.remaining
+	}
+	if !h.reset.IsZero() {
+		t.quota.Reset = h.reset
+	}
+	if h.retryAfter > 0 {
+		t.quota.BlockedUntil = now.Add(h.retryAfter)
+	}
+	if statusCode == http.StatusTooManyRequests {
+		t.rateLimited = append(t.rateLimited, now)
+	}
+}
+
+func (t *quotaTracker) snapshot() Quota {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cutoff := time.Now().Add(-rateLimitWindow)
+	recent := t.rateLimited[:0]
+	for _, at := range t.rateLimited {
+		if at.After(cutoff) {
+			recent = append(recent, at)
+		}
+	}
+	t.rateLimited = recent
+
+	quota := t.quota
+	quota.RecentRateLimits = len(recent)
+	return quota
+}
+
+// onAfterResponse feeds every response into the tracker
+func (t *quotaTracker) onAfterResponse(_ *resty.Client, resp *resty.Response) error {
+	t.observe(resp.StatusCode(), resp.Header())
+	return nil
+}
+
+// Quota reports the remaining request budget as tracked from recent responses
+func (c *Client) Quota() Quota {
+	return c.quota.snapshot()
+}
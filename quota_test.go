@@ -0,0 +1,59 @@
+package gospiget
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitErrorAndQuota(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		if r.URL.Path == "/resources/2" {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "60")
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL))
+	quota := c.Quota()
+	assert.Equal(t, -1, quota.Remaining)
+	assert.False(t, quota.Blocked())
+
+	_, err := c.GetResourceByID(1)
+	assert.NoError(t, err)
+	quota = c.Quota()
+	assert.Equal(t, 100, quota.Limit)
+	assert.Equal(t, 42, quota.Remaining)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), quota.Reset, 5*time.Second)
+	assert.Equal(t, 0, quota.RecentRateLimits)
+
+	_, err = c.GetResourceByID(2)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	var rateLimited *RateLimitError
+	assert.ErrorAs(t, err, &rateLimited)
+	assert.Equal(t, 30*time.Second, rateLimited.RetryAfter)
+	assert.Equal(t, 0, rateLimited.Remaining)
+	assert.Equal(t, http.StatusTooManyRequests, rateLimited.StatusCode)
+
+	quota = c.Quota()
+	assert.Equal(t, 0, quota.Remaining)
+	assert.Equal(t, 1, quota.RecentRateLimits)
+	assert.True(t, quota.Blocked())
+}
+
+func TestParseRetryAfterDate(t *testing.T) {
+	now := time.Now()
+	header := http.Header{"Retry-After": {now.Add(2 * time.Minute).UTC().Format(http.TimeFormat)}}
+	h := parseRateLimitHeaders(header, now)
+	assert.InDelta(t, (2 * time.Minute).Seconds(), h.retryAfter.Seconds(), 1)
+}
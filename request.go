@@ -0,0 +1,170 @@
+package gospiget
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// request describes a single API call made through do
+type request struct {
+	method string
+	// path is relative to the client's base URL, or an absolute URL
+	path   string
+	params map[string]string
+	form   url.Values
+	// noCache keeps the response cache out of the request
+	noCache bool
+	// icon sends the request with the client's icon transport instead of the API one
+	icon bool
+	// accept lists the successful status codes. Defaults to 200 only.
+	accept   []int
+	notFound notFound
+}
+
+// query returns the encoded query string, starting with "?" when not empty
+func (r request) query() string {
+	if len(r.params) == 0 {
+		return ""
+	}
+	query := url.Values{}
+	for k, v := range r.params {
+		query.Set(k, v)
+	}
+	return "?" + query.Encode()
+}
+
+// key identifies the request for coalescing identical in-flight calls
+func (r request) key() string {
+	key := r.method + " " + r.path + r.query()
+	if r.noCache {
+		key += " no-cache"
+	}
+	return key
+}
+
+// url returns the full URL of the request, for errors raised before it is sent
+func (r request) url(c *Client) string {
+	u := r.path
+	if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+		u = strings.TrimSuffix(c.baseURL, "/") + u
+	}
+	return u + r.query()
+}
+
+func (r request) accepts(status int) bool {
+	if len(r.accept) == 0 {
+		return status == http.StatusOK
+	}
+	for _, code := range r.accept {
+		if code == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Response is the raw HTTP response of an API call
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// Duration is the time from sending the request to reading the response
+	Duration time.Duration
+}
+
+func newResponse(resp *resty.Response) *Response {
+	return &Response{
+		StatusCode: resp.StatusCode(),
+		Header:     resp.Header(),
+		Body:       resp.Body(),
+		Duration:   resp.Time(),
+	}
+}
+
+// flightResult is what coalesced callers of do share
+type flightResult[T any] struct {
+	val  T
+	resp *Response
+}
+
+// do sends r and decodes a successful response into T. Every API call goes
+// through here, so status handling and error reporting are the same for all
+// endpoints:
+//   - 404 becomes a NotFoundError describing r.notFound
+//   - any other status outside r.accept becomes an UnexpectedStatusCodeError
+//     or RateLimitError
+//   - a []byte T receives the raw body and a struct{} T skips decoding
+//
+// Identical in-flight GET requests are coalesced into one and share the
+// decoded result, so returned values should be treated as read-only. The
+// Response is returned whenever one was received, including with errors.
+func do[T any](ctx context.Context, c *Client, r request) (T, *Response, error) {
+	send := func(ctx context.Context) (interface{}, error) {
+		var result flightResult[T]
+		client := c.restyClient
+		if r.icon {
+			client = c.iconClient
+		}
+		req := client.R().SetContext(ctx).SetQueryParams(r.params)
+		if r.form != nil {
+			req.SetFormDataFromValues(r.form)
+		}
+		if r.noCache {
+			req.SetHeader("Cache-Control", "no-cache")
+		}
+		resp, err := req.Execute(r.method, r.path)
+		if err != nil {
+			return result, newRequestError(req, err)
+		}
+		result.resp = newResponse(resp)
+		if resp.StatusCode() == http.StatusNotFound {
+			return result, newNotFoundError(req, resp, r.notFound)
+		}
+		if !r.accepts(resp.StatusCode()) {
+			return result, newStatusError(req, resp)
+		}
+		switch out := any(&result.val).(type) {
+		case *[]byte:
+			*out = resp.Body()
+		case *struct{}:
+		default:
+			if err := json.Unmarshal(resp.Body(), &result.val); err != nil {
+				return result, newUnmarshalError(req, resp, err)
+			}
+		}
+		return result, nil
+	}
+
+	var val interface{}
+	var err error
+	if r.method == http.MethodGet {
+		// Callers decoding into different types must not share a result
+		key := fmt.Sprintf("%s %T", r.key(), (*T)(nil))
+		val, err = c.flights.do(ctx, key, send)
+	} else {
+		val, err = send(ctx)
+	}
+	if err != nil && err == ctx.Err() {
+		// The caller stopped waiting for a coalesced request
+		err = newRequestError(&resty.Request{Method: r.method, URL: r.url(c)}, err)
+	}
+	result, _ := val.(flightResult[T])
+	if err != nil {
+		var zero T
+		return zero, result.resp, err
+	}
+	return result.val, result.resp, nil
+}
+
+// get is do for the common case of a GET that only needs the decoded body
+func get[T any](ctx context.Context, c *Client, path string, params map[string]string, nf notFound) (T, error) {
+	val, _, err := do[T](ctx, c, request{method: http.MethodGet, path: path, params: params, notFound: nf})
+	return val, err
+}
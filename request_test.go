@@ -0,0 +1,99 @@
+package gospiget
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDo(t *testing.T) {
+	var posts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		switch r.URL.Path {
+		case "/raw":
+			w.Write([]byte("raw body"))
+		case "/created":
+			atomic.AddInt32(&posts, 1)
+			time.Sleep(50 * time.Millisecond)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "abc"}`))
+		case "/empty":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("broken"))
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	body, resp, err := do[[]byte](ctx, c, request{method: http.MethodGet, path: "/raw"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("raw body"), body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "req-1", resp.Header.Get("X-Request-Id"))
+
+	_, _, err = do[struct{}](ctx, c, request{method: http.MethodDelete, path: "/empty", accept: []int{http.StatusNoContent}})
+	assert.NoError(t, err)
+
+	// Status codes outside accept are errors even when they are successful
+	_, _, err = do[struct{}](ctx, c, request{method: http.MethodDelete, path: "/empty"})
+	assert.IsType(t, &UnexpectedStatusCodeError{}, err)
+
+	// The response is available alongside the error
+	_, resp, err = do[*Resource](ctx, c, request{method: http.MethodGet, path: "/fail"})
+	assert.ErrorIs(t, err, ErrServerError)
+	assert.Equal(t, []byte("broken"), resp.Body)
+
+	// Only GET requests are coalesced
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			registration, _, err := do[*WebhookRegistration](ctx, c, request{method: http.MethodPost, path: "/created", accept: []int{http.StatusCreated}})
+			assert.NoError(t, err)
+			assert.Equal(t, "abc", registration.ID)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&posts))
+}
+
+func TestDoSeparatesResultTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`{"id": 1, "name": "Resource"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL))
+	ctx := context.Background()
+	r := request{method: http.MethodGet, path: "/resources/1"}
+
+	// Concurrent calls for the same path but different types are not coalesced
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		resource, _, err := do[*Resource](ctx, c, r)
+		assert.NoError(t, err)
+		assert.Equal(t, "Resource", resource.Name)
+	}()
+	go func() {
+		defer wg.Done()
+		body, _, err := do[[]byte](ctx, c, r)
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), "Resource")
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,46 @@
+package gospiget
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SearchField selects which field a search matches against
+type SearchField string
+
+// Searchable fields
+const (
+	SearchByName SearchField = "name"
+	SearchByTag  SearchField = "tag"
+)
+
+// SearchOptions are typed query parameters for SearchResources and SearchAuthors.
+// Zero values are left out of the request.
+type SearchOptions struct {
+	Field  SearchField
+	Size   int
+	Page   int
+	Sort   string
+	Fields []string
+}
+
+// Params converts the options to query parameters
+func (o SearchOptions) Params() Params {
+	params := Params{}
+	if o.Field != "" {
+		params["field"] = string(o.Field)
+	}
+	if o.Size > 0 {
+		params["size"] = strconv.Itoa(o.Size)
+	}
+	if o.Page > 0 {
+		params["page"] = strconv.Itoa(o.Page)
+	}
+	if o.Sort != "" {
+		params["sort"] = o.Sort
+	}
+	if len(o.Fields) > 0 {
+		params["fields"] = strings.Join(o.Fields, ",")
+	}
+	return params
+}
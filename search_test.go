@@ -0,0 +1,41 @@
+package gospiget
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchEscapesQuery(t *testing.T) {
+	var rawPath string
+	var query map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawPath = r.URL.EscapedPath()
+		query = r.URL.Query()
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL))
+	_, err := c.SearchResources("world edit/tools?#ü", SearchOptions{Field: SearchByTag, Size: 5}.Params())
+	assert.NoError(t, err)
+	assert.Equal(t, "/search/resources/world%20edit%2Ftools%3F%23%C3%BC", rawPath)
+	assert.Equal(t, "tag", query["field"][0])
+	assert.Equal(t, "5", query["size"][0])
+
+	_, err = c.SearchAuthors("md_5", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "/search/authors/md_5", rawPath)
+
+	_, err = c.GetResourcesForVersions([]string{"1.20.4", "1.21 beta"}, MatchAny, nil)
+	assert.Error(t, err) // the stand-in returns a list, not a match structure
+	assert.Equal(t, "/resources/for/1.20.4,1.21%20beta", rawPath)
+}
+
+func TestSearchOptionsParams(t *testing.T) {
+	assert.Equal(t, Params{}, SearchOptions{}.Params())
+	assert.Equal(t, Params{"field": "name", "page": "2", "sort": "-downloads", "fields": "id,name"},
+		SearchOptions{Field: SearchByName, Page: 2, Sort: "-downloads", Fields: []string{"id", "name"}}.Params())
+}
@@ -0,0 +1,73 @@
+package gospiget
+
+import (
+	"context"
+	"sync"
+)
+
+// flightCall is an in-flight or completed request shared by its callers
+type flightCall struct {
+	done    chan struct{}
+	val     interface{}
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// flightGroup coalesces concurrent calls with the same key into one execution
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// do runs fn once for all concurrent callers of key and hands each of them the
+// same result. fn runs detached from the first caller's cancellation so that
+// one caller giving up does not fail the others. Each caller stops waiting
+// when its own ctx is done, and fn's context is cancelled once every caller
+// has stopped waiting.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	call, ok := g.calls[key]
+	if !ok {
+		runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &flightCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+		go g.run(runCtx, key, call, fn)
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.val, call.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			call.cancel()
+			// Later callers must not join the cancelled call
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (g *flightGroup) run(ctx context.Context, key string, call *flightCall, fn func(ctx context.Context) (interface{}, error)) {
+	defer func() {
+		g.mu.Lock()
+		if g.calls[key] == call {
+			delete(g.calls, key)
+		}
+		g.mu.Unlock()
+		call.cancel()
+		close(call.done)
+	}()
+
+	call.val, call.err = fn(ctx)
+}
@@ -0,0 +1,93 @@
+package gospiget
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientCoalescesRequests(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte(`{"id": 1, "name": "Popular"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL))
+
+	var wg sync.WaitGroup
+	results := make([]*Resource, 50)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resource, err := c.GetResourceByID(1)
+			assert.NoError(t, err)
+			results[i] = resource
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+	for _, resource := range results {
+		assert.Same(t, results[0], resource)
+	}
+
+	// Requests that differ only in query parameters must not be merged
+	atomic.StoreInt32(&hits, 0)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		c.GetResources(map[string]string{"page": "1"})
+	}()
+	go func() {
+		defer wg.Done()
+		c.GetResources(map[string]string{"page": "2"})
+	}()
+	wg.Wait()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&hits))
+}
+
+func TestCoalescedRequestCancelled(t *testing.T) {
+	cancelled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(cancelled)
+	}))
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// The request is only cancelled once every waiting caller has given up
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := get[*Resource](ctx, c, "/resources/1", nil, lookup("resource", 1))
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+			var requestErr *RequestError
+			if assert.ErrorAs(t, err, &requestErr) {
+				assert.Equal(t, http.MethodGet, requestErr.Method)
+				assert.Equal(t, server.URL+"/resources/1", requestErr.URL)
+			}
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case <-cancelled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("request was not cancelled")
+	}
+}
@@ -0,0 +1,219 @@
+package gospiget
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// WatchEvent is emitted by a Watcher. It is either a VersionReleased or an UpdatePosted.
+type WatchEvent interface {
+	watchEvent()
+}
+
+// VersionReleased is emitted when the latest version of a watched resource changes
+type VersionReleased struct {
+	ResourceID int
+	Version    ResourceVersion
+}
+
+// UpdatePosted is emitted when a watched resource posts a new update
+type UpdatePosted struct {
+	ResourceID int
+	Update     ResourceUpdate
+}
+
+func (VersionReleased) watchEvent() {}
+func (UpdatePosted) watchEvent()    {}
+
+// WatchState is the last version and update seen for a resource
+type WatchState struct {
+	LastVersionID int `json:"lastVersionId"`
+	LastUpdateID  int `json:"lastUpdateId"`
+}
+
+// WatchStore persists watcher state between runs
+type WatchStore interface {
+	Load() (map[int]WatchState, error)
+	Save(state map[int]WatchState) error
+}
+
+// FileWatchStore keeps watcher state in a JSON file
+type FileWatchStore struct {
+	Path string
+}
+
+func (s *FileWatchStore) Load() (map[int]WatchState, error) {
+	state := map[int]WatchState{}
+	if err := readJSONFile(s.Path, &state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func (s *FileWatchStore) Save(state map[int]WatchState) error {
+	return writeJSONFile(s.Path, state)
+}
+
+// WatcherOptions configures a Watcher
+type WatcherOptions struct {
+	// Interval between polls of the full resource set. Defaults to 10 minutes.
+	Interval time.Duration
+	// Jitter randomises each interval by up to this fraction. Defaults to 0.1.
+	Jitter float64
+	// MinRequestInterval is the minimum delay between two API requests. Defaults to 1 second.
+	MinRequestInterval time.Duration
+	// Store persists the last seen IDs. State is kept in memory when nil.
+	Store WatchStore
+	// OnError is called for failed polls and store errors. Errors are dropped when nil.
+	OnError func(resourceID int, err error)
+}
+
+// Watcher polls resources for new versions and updates.
+// The first poll of a resource without stored state only records a baseline.
+type Watcher struct {
+	client   *Client
+	ids      []int
+	opts     WatcherOptions
+	schedule *pollSchedule
+	events   chan WatchEvent
+
+	mu    sync.Mutex
+	state map[int]WatchState
+}
+
+// NewWatcher creates a Watcher for the given resource IDs
+func NewWatcher(client *Client, resourceIDs []int, opts WatcherOptions) *Watcher {
+	return &Watcher{
+		client:   client,
+		ids:      resourceIDs,
+		opts:     opts,
+		schedule: newPollSchedule(opts.Interval, opts.Jitter, opts.MinRequestInterval),
+		events:   make(chan WatchEvent, 16),
+		state:    map[int]WatchState{},
+	}
+}
+
+// Events returns the channel events are delivered on. It is closed when Run returns.
+func (w *Watcher) Events() <-chan WatchEvent {
+	return w.events
+}
+
+// State returns a copy of the last seen IDs per resource
+func (w *Watcher) State() map[int]WatchState {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	state := make(map[int]WatchState, len(w.state))
+	for id, s := range w.state {
+		state[id] = s
+	}
+	return state
+}
+
+// Run polls until ctx is cancelled. It returns ctx.Err() on shutdown or the
+// error from loading the store. State is saved only after a resource's events
+// have been delivered, so an event interrupted by shutdown is emitted again
+// on the next run.
+func (w *Watcher) Run(ctx context.Context) error {
+	defer close(w.events)
+
+	if w.opts.Store != nil {
+		state, err := w.opts.Store.Load()
+		if err != nil {
+			return err
+		}
+		w.mu.Lock()
+		for id, s := range state {
+			w.state[id] = s
+		}
+		w.mu.Unlock()
+	}
+
+	for {
+		for _, id := range w.ids {
+			if err := w.poll(ctx, id); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				w.reportError(id, err)
+			}
+		}
+
+		if err := w.schedule.waitRound(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (w *Watcher) poll(ctx context.Context, resourceID int) error {
+	w.mu.Lock()
+	prev, known := w.state[resourceID]
+	w.mu.Unlock()
+	next := prev
+
+	if err := w.schedule.waitRequest(ctx); err != nil {
+		return err
+	}
+	version, err := w.client.latestVersion(ctx, resourceID, nil, true)
+	var missing *NotFoundError
+	if err != nil && !errors.As(err, &missing) {
+		return err
+	}
+	if version != nil {
+		next.LastVersionID = version.ID
+	}
+
+	if err := w.schedule.waitRequest(ctx); err != nil {
+		return err
+	}
+	update, err := w.client.latestUpdate(ctx, resourceID, nil, true)
+	if err != nil && !errors.As(err, &missing) {
+		return err
+	}
+	if update != nil {
+		next.LastUpdateID = update.ID
+	}
+
+	if next == prev && known {
+		return nil
+	}
+
+	if known {
+		if version != nil && next.LastVersionID != prev.LastVersionID {
+			if err := w.emit(ctx, VersionReleased{ResourceID: resourceID, Version: *version}); err != nil {
+				return err
+			}
+		}
+		if update != nil && next.LastUpdateID != prev.LastUpdateID {
+			if err := w.emit(ctx, UpdatePosted{ResourceID: resourceID, Update: *update}); err != nil {
+				return err
+			}
+		}
+	}
+
+	w.mu.Lock()
+	w.state[resourceID] = next
+	w.mu.Unlock()
+	if w.opts.Store != nil {
+		if err := w.opts.Store.Save(w.State()); err != nil {
+			w.reportError(resourceID, err)
+		}
+	}
+	return nil
+}
+
+func (w *Watcher) emit(ctx context.Context, event WatchEvent) error {
+	select {
+	case w.events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *Watcher) reportError(resourceID int, err error) {
+	if w.opts.OnError != nil {
+		w.opts.OnError(resourceID, err)
+	}
+}
@@ -0,0 +1,100 @@
+package gospiget
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcher(t *testing.T) {
+	var versionPolls int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/resources/1/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		// The second poll sees a new version
+		id := 10
+		if atomic.AddInt32(&versionPolls, 1) > 1 {
+			id = 11
+		}
+		fmt.Fprintf(w, `{"id": %d, "resource": 1, "name": "1.%d"}`, id, id)
+	})
+	mux.HandleFunc("/resources/1/updates/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 100, "resource": 1, "title": "Initial"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	store := &FileWatchStore{Path: filepath.Join(t.TempDir(), "state.json")}
+	// Polls must not be answered from the response cache
+	client := NewClient(WithBaseURL(server.URL), WithCache(NewMemoryCache(), 0))
+	watcher := NewWatcher(client, []int{1}, WatcherOptions{
+		Interval:           10 * time.Millisecond,
+		MinRequestInterval: time.Millisecond,
+		Store:              store,
+		OnError:            func(id int, err error) { t.Error(id, err) },
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- watcher.Run(ctx) }()
+
+	event := <-watcher.Events()
+	released, ok := event.(VersionReleased)
+	assert.True(t, ok)
+	assert.Equal(t, 1, released.ResourceID)
+	assert.Equal(t, 11, released.Version.ID)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	_, open := <-watcher.Events()
+	assert.False(t, open)
+
+	state, err := store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, WatchState{LastVersionID: 11, LastUpdateID: 100}, state[1])
+}
+
+// nilWatchStore has no state on the first run
+type nilWatchStore struct {
+	saved chan map[int]WatchState
+}
+
+func (s *nilWatchStore) Load() (map[int]WatchState, error) {
+	return nil, nil
+}
+
+func (s *nilWatchStore) Save(state map[int]WatchState) error {
+	s.saved <- state
+	return nil
+}
+
+func TestWatcherNilStoreState(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 10, "resource": 1}`))
+	}))
+	defer server.Close()
+
+	store := &nilWatchStore{saved: make(chan map[int]WatchState, 1)}
+	watcher := NewWatcher(NewClient(WithBaseURL(server.URL)), []int{1}, WatcherOptions{
+		Interval:           time.Hour,
+		MinRequestInterval: time.Millisecond,
+		Store:              store,
+		OnError:            func(id int, err error) { t.Error(id, err) },
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- watcher.Run(ctx) }()
+
+	assert.Equal(t, WatchState{LastVersionID: 10, LastUpdateID: 10}, (<-store.saved)[1])
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
@@ -0,0 +1,87 @@
+package gospiget
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// WebhookEvent is an event type a webhook can subscribe to
+type WebhookEvent string
+
+// Webhook events supported by Spiget
+const (
+	EventResourceUpdate WebhookEvent = "resource_update"
+	EventNewResource    WebhookEvent = "new_resource"
+	EventNewAuthor      WebhookEvent = "new_author"
+)
+
+// WebhookRegistration identifies a registered webhook. The secret is required to delete it.
+type WebhookRegistration struct {
+	ID     string `json:"id"`
+	Secret string `json:"secret"`
+}
+
+// WebhookStatus represents the delivery status of a webhook
+type WebhookStatus struct {
+	Status            int `json:"status"`
+	FailedConnections int `json:"failedConnections"`
+}
+
+// Failed reports whether Spiget failed to deliver to the webhook URL
+func (s WebhookStatus) Failed() bool {
+	return s.FailedConnections > 0
+}
+
+func (c *Client) RegisterWebhook(webhookURL string, events []WebhookEvent) (*WebhookRegistration, error) {
+	form := url.Values{"url": {webhookURL}}
+	for _, event := range events {
+		form.Add("events", string(event))
+	}
+	registration, _, err := do[*WebhookRegistration](context.Background(), c, request{
+		method:   http.MethodPost,
+		path:     "/webhook/register",
+		form:     form,
+		accept:   []int{http.StatusOK, http.StatusCreated},
+		notFound: notFound{message: "webhook registration not found"},
+	})
+	return registration, err
+}
+
+func (c *Client) GetWebhookStatus(webhookID string) (*WebhookStatus, error) {
+	return get[*WebhookStatus](context.Background(), c, fmt.Sprintf("/webhook/status/%s", url.PathEscape(webhookID)), nil, lookup("webhook", webhookID))
+}
+
+func (c *Client) DeleteWebhook(registration WebhookRegistration) error {
+	secret := url.PathEscape(registration.Secret)
+	_, _, err := do[struct{}](context.Background(), c, request{
+		method:   http.MethodDelete,
+		path:     fmt.Sprintf("/webhook/delete/%s/%s", url.PathEscape(registration.ID), secret),
+		accept:   []int{http.StatusOK, http.StatusNoContent},
+		notFound: lookup("webhook", registration.ID),
+	})
+	return redactSecret(err, secret)
+}
+
+// redactSecret keeps a webhook secret that is part of the request path out of errors
+func redactSecret(err error, secret string) error {
+	if secret == "" {
+		return err
+	}
+	redact := func(s string) string {
+		return strings.ReplaceAll(s, secret, "REDACTED")
+	}
+	switch e := err.(type) {
+	case *RequestError:
+		e.Message, e.URL = redact(e.Message), redact(e.URL)
+	case *NotFoundError:
+		e.URL = redact(e.URL)
+	case *UnexpectedStatusCodeError:
+		e.URL = redact(e.URL)
+	case *RateLimitError:
+		e.URL = redact(e.URL)
+	}
+	return err
+}
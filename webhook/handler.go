@@ -0,0 +1,125 @@
+// Package webhook receives Spiget webhook calls and dispatches them as typed events.
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/Mark7888/gospiget"
+)
+
+// maxPayloadSize limits the size of an accepted webhook body
+const maxPayloadSize = 1 << 20
+
+// payload is the envelope Spiget posts for every event
+type payload struct {
+	Event gospiget.WebhookEvent `json:"event"`
+	Body  json.RawMessage       `json:"body"`
+}
+
+// NewResourceEvent is sent when a new resource is published
+type NewResourceEvent struct {
+	gospiget.Resource
+}
+
+// ResourceUpdateEvent is sent when a resource posts an update
+type ResourceUpdateEvent struct {
+	gospiget.ResourceUpdate
+}
+
+// NewAuthorEvent is sent when a new author is registered
+type NewAuthorEvent struct {
+	gospiget.Author
+}
+
+// Handler is an http.Handler that parses webhook payloads and calls the
+// callbacks registered for the event type. Callbacks run synchronously in
+// registration order before the response is written.
+type Handler struct {
+	mu               sync.RWMutex
+	onNewResource    []func(NewResourceEvent)
+	onResourceUpdate []func(ResourceUpdateEvent)
+	onNewAuthor      []func(NewAuthorEvent)
+}
+
+// NewHandler creates a Handler without callbacks
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+// OnNewResource registers a callback for new_resource events
+func (h *Handler) OnNewResource(fn func(NewResourceEvent)) {
+	h.mu.Lock()
+	h.onNewResource = append(h.onNewResource, fn)
+	h.mu.Unlock()
+}
+
+// OnResourceUpdate registers a callback for resource_update events
+func (h *Handler) OnResourceUpdate(fn func(ResourceUpdateEvent)) {
+	h.mu.Lock()
+	h.onResourceUpdate = append(h.onResourceUpdate, fn)
+	h.mu.Unlock()
+}
+
+// OnNewAuthor registers a callback for new_author events
+func (h *Handler) OnNewAuthor(fn func(NewAuthorEvent)) {
+	h.mu.Lock()
+	h.onNewAuthor = append(h.onNewAuthor, fn)
+	h.mu.Unlock()
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var p payload
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPayloadSize)).Decode(&p); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	// Callbacks run without the lock so they can register further callbacks.
+	// Registration only appends, so the copied slices stay valid.
+	h.mu.RLock()
+	onNewResource, onResourceUpdate, onNewAuthor := h.onNewResource, h.onResourceUpdate, h.onNewAuthor
+	h.mu.RUnlock()
+
+	switch p.Event {
+	case gospiget.EventNewResource:
+		var event NewResourceEvent
+		if err := json.Unmarshal(p.Body, &event); err != nil {
+			http.Error(w, "invalid event body", http.StatusBadRequest)
+			return
+		}
+		for _, fn := range onNewResource {
+			fn(event)
+		}
+	case gospiget.EventResourceUpdate:
+		var event ResourceUpdateEvent
+		if err := json.Unmarshal(p.Body, &event); err != nil {
+			http.Error(w, "invalid event body", http.StatusBadRequest)
+			return
+		}
+		for _, fn := range onResourceUpdate {
+			fn(event)
+		}
+	case gospiget.EventNewAuthor:
+		var event NewAuthorEvent
+		if err := json.Unmarshal(p.Body, &event); err != nil {
+			http.Error(w, "invalid event body", http.StatusBadRequest)
+			return
+		}
+		for _, fn := range onNewAuthor {
+			fn(event)
+		}
+	default:
+		http.Error(w, "unknown event", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
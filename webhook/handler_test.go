@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	newResourcePayload    = `{"event": "new_resource", "body": {"id": 12345, "name": "ExamplePlugin", "tag": "Does things", "author": {"id": 42}}}`
+	resourceUpdatePayload = `{"event": "resource_update", "body": {"id": 777, "resource": 12345, "title": "v1.2.0", "date": 1700000000, "likes": 3}}`
+	newAuthorPayload      = `{"event": "new_author", "body": {"id": 42, "name": "Notch"}}`
+)
+
+func post(t *testing.T, server *httptest.Server, body string) int {
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(body))
+	assert.NoError(t, err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestHandlerDispatchesEvents(t *testing.T) {
+	h := NewHandler()
+
+	var newResources []NewResourceEvent
+	var updates []ResourceUpdateEvent
+	var authors []NewAuthorEvent
+	h.OnNewResource(func(e NewResourceEvent) { newResources = append(newResources, e) })
+	h.OnResourceUpdate(func(e ResourceUpdateEvent) { updates = append(updates, e) })
+	h.OnNewAuthor(func(e NewAuthorEvent) { authors = append(authors, e) })
+
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	assert.Equal(t, http.StatusOK, post(t, server, newResourcePayload))
+	assert.Equal(t, http.StatusOK, post(t, server, resourceUpdatePayload))
+	assert.Equal(t, http.StatusOK, post(t, server, newAuthorPayload))
+
+	assert.Len(t, newResources, 1)
+	assert.Equal(t, 12345, newResources[0].ID)
+	assert.Equal(t, "ExamplePlugin", newResources[0].Name)
+	assert.Equal(t, 42, newResources[0].Author.ID)
+
+	assert.Len(t, updates, 1)
+	assert.Equal(t, 12345, updates[0].Resource)
+	assert.Equal(t, "v1.2.0", updates[0].Title)
+
+	assert.Len(t, authors, 1)
+	assert.Equal(t, "Notch", authors[0].Name)
+}
+
+func TestHandlerRejectsInvalidRequests(t *testing.T) {
+	server := httptest.NewServer(NewHandler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	assert.Equal(t, http.StatusBadRequest, post(t, server, `not json`))
+	assert.Equal(t, http.StatusBadRequest, post(t, server, `{"event": "something_else", "body": {}}`))
+	assert.Equal(t, http.StatusBadRequest, post(t, server, `{"event": "new_author", "body": "oops"}`))
+}
+
+func TestHandlerCallbackRegistersCallback(t *testing.T) {
+	h := NewHandler()
+	var calls int
+	h.OnNewResource(func(NewResourceEvent) {
+		calls++
+		// Registering from a callback must not deadlock
+		h.OnNewResource(func(NewResourceEvent) {})
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	assert.Equal(t, http.StatusOK, post(t, server, newResourcePayload))
+	assert.Equal(t, 1, calls)
+}
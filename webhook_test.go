@@ -0,0 +1,44 @@
+package gospiget
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookLifecycle(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/webhook/register", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "https://example.com/hook", r.PostForm.Get("url"))
+		assert.Equal(t, []string{"resource_update", "new_resource"}, r.PostForm["events"])
+		w.Write([]byte(`{"id": "abc", "secret": "s3cret"}`))
+	})
+	mux.HandleFunc("/webhook/status/abc", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status": 1, "failedConnections": 3}`))
+	})
+	mux.HandleFunc("/webhook/delete/abc/s3cret", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		w.WriteHeader(http.StatusOK)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL))
+	registration, err := c.RegisterWebhook("https://example.com/hook", []WebhookEvent{EventResourceUpdate, EventNewResource})
+	assert.NoError(t, err)
+	assert.Equal(t, &WebhookRegistration{ID: "abc", Secret: "s3cret"}, registration)
+
+	status, err := c.GetWebhookStatus(registration.ID)
+	assert.NoError(t, err)
+	assert.True(t, status.Failed())
+	assert.Equal(t, 3, status.FailedConnections)
+
+	_, err = c.GetWebhookStatus("unknown")
+	assert.IsType(t, &NotFoundError{}, err)
+
+	assert.NoError(t, c.DeleteWebhook(*registration))
+}